@@ -23,11 +23,20 @@ type Lexer interface {
 // TagLexer is a tag lexer.
 type TagLexer struct {
 	Init     func(map[string][]*taginfo.Taginfo, *regexp.Regexp, map[string]bool)
+	Delims   func(Delims)
 	Lex      LexFunc
 	NotFirst bool
 	Once     bool
 }
 
+// SetDelims sets the delimiters used by the lexer. Called by the tag parser
+// prior to Initialize.
+func (lexer TagLexer) SetDelims(delims Delims) {
+	if lexer.Delims != nil {
+		lexer.Delims(delims)
+	}
+}
+
 // Init satisfies the Lexer interface.
 func (lexer TagLexer) Initialize(infos map[string][]*taginfo.Taginfo, delim *regexp.Regexp, short map[string]bool) (LexFunc, bool, bool) {
 	if lexer.Init != nil {
@@ -38,6 +47,23 @@ func (lexer TagLexer) Initialize(infos map[string][]*taginfo.Taginfo, delim *reg
 
 // DefaultLexers returns the default tag tag lexers.
 func DefaultLexers() []Lexer {
+	lexers, err := DefaultLexersWithPatterns()
+	if err != nil {
+		panic(err)
+	}
+	return lexers
+}
+
+// DefaultLexersWithPatterns returns the default tag lexers, adding the passed
+// lexer patterns to the default lexer patterns. Returns an error when any of
+// the patterns is invalid.
+//
+// See DefaultLexerPatterns and LoadLexerPatterns.
+func DefaultLexersWithPatterns(patterns ...LexerPattern) ([]Lexer, error) {
+	m, err := buildLexerPatterns(append(DefaultLexerPatterns(), patterns...)...)
+	if err != nil {
+		return nil, err
+	}
 	return []Lexer{
 		// --------------- once ---------------
 		NewTrimWhitespaceLexer(),
@@ -53,6 +79,16 @@ func DefaultLexers() []Lexer {
 			`req`, `{`, `}`, `(REQ(?:UEST)?)`,
 			// [ABCD1234]
 			`sum`, `[`, `]`, `([0-9A-F]{8})`,
+			// {imdb-tt0133093}, [imdbid-tt0133093], [tt0133093], tt0133093
+			`imdb`, `{`, `}`, `(?:(?i:imdb(?:id)?)[\-_:= ]?)?(tt\d{7,8})`,
+			`imdb`, `[`, `]`, `(?:(?i:imdb(?:id)?)[\-_:= ]?)?(tt\d{7,8})`,
+			`imdb`, ``, ``, `\b(tt\d{7,8})\b`,
+			// {tmdb-603}, [tmdbid-603]
+			`tmdb`, `{`, `}`, `(?i:tmdb(?:id)?)[\-_:= ]?(\d{1,8})`,
+			`tmdb`, `[`, `]`, `(?i:tmdb(?:id)?)[\-_:= ]?(\d{1,8})`,
+			// {tvdb-12345}, [tvdbid-12345]
+			`tvdb`, `{`, `}`, `(?i:tvdb(?:id)?)[\-_:= ]?(\d{1,8})`,
+			`tvdb`, `[`, `]`, `(?i:tvdb(?:id)?)[\-_:= ]?(\d{1,8})`,
 			// [site]
 			`site`, `[`, `]`, `([^ \t\]]{1,32})`,
 			// -={site}=-
@@ -63,107 +99,42 @@ func DefaultLexers() []Lexer {
 		NewGroupLexer(),
 		// --------------- multi ---------------
 		NewRegexpLexer(TagTypeSize, true),
+		NewPlatformVersionLexer(),
 		NewRegexpLexer(TagTypePlatform, true),
 		NewRegexpLexer(TagTypeArch, true),
 		NewRegexpLexer(TagTypeSource, true),
+		NewFrameRateLexer(),
 		NewRegexpLexer(TagTypeResolution, true),
 		NewRegexpSourceLexer(TagTypeCollection, true),
-		NewSeriesLexer(
-			// s02, S01E01
-			`(?i)^s(?P<s>[0-8]?\d)[\-\._ ]?(?:e(?P<e>\d{1,5}))?\b`,
-			// S01E02E03, S01E02-E03, S01E03.E04.E05
-			`(?i)^s(?P<s>[0-8]?\d)(?P<m>(?:[\-\._ ]?e\d{1,5}){1,5})\b`,
-			// S01S02S03
-			`(?i)^(?P<S>(?:s[0-8]?\d){2,4})\b`,
-			// 2x1, 1x01
-			`(?i)^(?P<s>[0-8]?\d)x(?P<e>\d{1,3})\b`,
-			// S01 - 02v3, S07-06, s03-5v.9
-			`(?i)^s(?P<s>[0-8]?\d)[\-\._ ]{1,3}(?P<e>\d{1,5})(?:[\-\._ ]{1,3}(?P<v>v\d+(?:\.\d+){0,2}))?\b`,
-			// Season.01.Episode.02, Series.01.Ep.02, Series.01, Season.01
-			`(?i)^(?:series|season|s)[\-\._ ]?(?P<s>[0-8]?\d)(?:[\-\._ ]?(?:episode|ep)(?P<e>\d{1,5}))?\b`,
-			// Vol.1.No.2, vol1no2
-			`(?i)^vol(?:ume)?[\-\._ ]?(?P<s>\d{1,3})(?:[\-\._ ]?(?:number|no)[\-\._ ]?(?P<e>\d{1,5}))\b`,
-			// Episode 15, E009, Ep. 007, Ep.05-07
-			`(?i)^e(?:p(?:isode)?[\-\._ ]{1,3})?(?P<e>\d{1,5})(?:[\-\._ ]{1,3}\d{1,3})?\b`,
-			// 10v1.7, 13v2
-			`(?i)^(?P<e>\d{1,5})(?P<v>v[\-\._ ]?\d+(?:\.\d){0,2})\b`,
-			// S01.Disc02, s01D3, Series.01.Disc.02, S02DVD3
-			`(?i)^(?:series|season|s)[\-\._ ]?(?P<s>[0-8]?\d)[\-\._ ]?(?P<d>(?:disc|disk|dvd|d)[\-\._ ]?(?:\d{1,3}))\b`,
-			// s1957e01
-			`(?i)^s(?P<s>19\d\d)e(?P<e>\d{2,4})\b`,
-		),
-		NewDiscSourceYearLexer(
-			// VLS2004, 2DVD1999, 4CD2003
-			`(?i)^(?P<d>[2-9])?(?P<s>cd|ep|lp|dvd|vls|vinyl)(?P<y>(?:19|20)\d\d)\b`,
-			// WEB2007
-			`(?i)^(?P<s>web)(?P<y>20\d\d)\b`,
-		),
-		NewDiscLexer(
-			// D01, Disc.1
-			`(?i)^(?P<t>d)(?:is[ck][\-\._ ])?(?P<c>\d{1,3})\b`,
-			// 12DiSCS
-			`(?i)^(?P<c>\d{1,3})[\-\._ ]?di(?P<t>s)[ck]s?\b`,
-			// CD1, CD30
-			`(?i)^(?P<t>cd)[\-\._ ]?(?P<c>\d{1,2})\b`,
-			// DVD2, DVD24 -- does not match DVD5/DVD9
-			`(?i)^(?P<t>dvd)[\-\._ ]?(?P<c>[1-46-8]|[12]\d)\b`,
-			// 2xDVD9
-			`(?i)^(?P<c>\d{1,2})(?P<t>x(?:dvd9))\b`,
-			// 2DVD9, 6DVD9
-			`(?i)^(?P<c>[2-9])(?P<z>dvd9)\b`,
-			// 2xVinyl, 3xDVD, 4xCD
-			`(?i)^(?P<c>\d{1,2})(?P<t>x(?:cd|ep|lp|dvda|dvd|vls|vinyl)s?)\b`,
-			// 2Vinyl, 6DVD
-			`(?i)^(?P<c>\d{1,2})(?P<x>(?:cd|ep|lp|dvda|dvd|vls|vinyl)s?)\b`,
-			// CDS3
-			`(?i)^(?:(?P<x>cd)s)(?P<c>\d{1,2})\b`,
-			// 2CDS
-			`(?i)^(?P<c>[2-9])(?P<x>cds)\b`,
-		),
-		NewDateLexer(
-			// 2006-01-02, 2006
-			`(?i)^(?P<2006>(?:19|20)\d{2})(?:[\-\._ ](?P<01>\d{2})[\-\._ ](?P<02>\d{2}))?\b`,
-			// 2006-01
-			`(?i)^(?P<2006>(?:19|20)\d{2})?:[\-\._ ](?P<01>\d{2})\b`,
-			// 13-02-2006
-			`(?i)^(?P<01>\d{2})[\-\._ ](?P<02>\d{2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
-			// 02-13-2006
-			`(?i)^(?P<02>\d{2})[\-\._ ](?P<01>\d{2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
-			// 2nd Jan 2006, 13 Dec 2011, Nov 1999
-			`(?i)^(?:(?P<_2>\d{1,2})(?:th|st|nd|rd)?[\-\._ ])?(?P<Jan>Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
-			// 01-August-1998
-			`(?i)^(?P<_2>\d{1,2})[\-\._ ](?P<January>January|February|March|April|May|June|July|August|September|October|November|December)[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
-			// MAY-30-1992
-			`(?i)^(?P<Jan>Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[\-\._ ](?P<_2>\d{1,2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
-			// 17.12.15, 20-9-9
-			`(?i)^(?P<YY>[12]\d)[\-\._ ](?P<01>\d\d?)[\-\._ ](?P<02>\d\d?)\b`,
-		),
-		NewVersionLexer(
-			// v1.17, v1, v1.2a, v1b
-			`(?i)^(version[\-\._ ])?(?P<v>v[\-\._ ]?\d{1,2}(?:[\._ ]\d{1,2}[a-z]?\d*){0,3})\b`,
-			// v2012, v20120803, v20120803, v1999.08.08
-			`(?i)^(version[\-\._ ])?(?P<v>v[\-\._ ]?(?:19|20)\d\d(?:[\-\._ ]?\d\d?){0,2})\b`,
-			// v60009
-			`(?i)^(version[\-\._ ])?(?P<v>v[\-\._ ]?\d{4,10})\b`,
-			// Version 2004, Version 21H2, Version 22H1
-			`(?i)^version[\-\._ ](?P<V>\d{2,}|\d{2}[a-z]{1,2}\d{1,2})\b`,
-			// 11.09.1, 100.000.99999999999, 23.3.2.458
-			`(?i)^(?P<u>\d{1,3}\.\d{1,3}\.\d{1,16}(\.\d{1,16})?)\b`,
-		),
+		NewRegexpLexer(TagTypeLeague, true),
+		NewMusicQualityLexer(),
+		NewISBNLexer(),
+		NewRoundLexer(),
+		NewSeriesLexer(m["series"]...),
+		NewDiscSourceYearLexer(m["discsourceyear"]...),
+		NewDiscLexer(m["disc"]...),
+		NewPartLexer(),
+		NewDateLexer(m["date"]...),
+		NewPixelFormatLexer(),
+		NewRegexpLexer(TagTypeChroma, true),
+		NewVersionLexer(m["version"]...),
 		NewRegexpSourceLexer(TagTypeCodec, true),
+		NewRegexpLexer(TagTypeBitDepth, true),
 		NewRegexpSourceLexer(TagTypeHDR, true),
 		NewAudioLexer(),
 		NewRegexpLexer(TagTypeChannels, true),
+		NewThreeDLexer(),
 		NewRegexpLexer(TagTypeOther, true),
 		NewRegexpLexer(TagTypeCut, true),
 		NewRegexpLexer(TagTypeEdition, true),
 		NewRegexpLexer(TagTypeLanguage, false),
 		NewRegexpLexer(TagTypeRegion, true),
 		NewRegexpLexer(TagTypeContainer, true),
+		NewLabelLexer(),
 		NewGenreLexer(),
 		NewIDLexer(),
 		NewEpisodeLexer(),
-	}
+	}, nil
 }
 
 // NewTrimWhitespaceLexer creates a tag lexer that matches leading and ending
@@ -282,6 +253,9 @@ func NewSeriesLexer(strs ...string) Lexer {
 				if len(version) != 0 {
 					tags = append(tags, NewTag(TagTypeVersion, nil, version, version))
 				}
+				if len(disc) != 0 && !dsc.Match(disc) {
+					return start, end, i, n, false
+				}
 				if len(disc) != 0 {
 					disctyp := bytes.ToUpper(disc[:len(dsc.FindSubmatch(disc)[0])])
 					num := bytes.TrimSpace(disc[len(disctyp):])
@@ -315,12 +289,23 @@ func NewSeriesLexer(strs ...string) Lexer {
 	}
 }
 
-// NewIDLexer creates a tag lexer for a music id.
+// NewIDLexer creates a tag lexer for a music id, and for IMDb, TMDb, and TVDb
+// ids within the text (`{imdb-tt0133093}`, `[tmdbid-603]`). Bare IMDb ids are
+// only lexed when bracketed or delimited (`.tt0133093.`, `(tt0133093)`).
 func NewIDLexer() Lexer {
 	alpha, digit, ws := regexp.MustCompile(`[A-Z]`), regexp.MustCompile(`\d`), regexp.MustCompile(`[\-\._ ]`)
 	re, lb := regexp.MustCompile(`^([A-Z\d\-\_\. ]{2,24})\)`), regexp.MustCompile(`\([\._ ]{0,2}$`)
+	db, dblb := regexp.MustCompile(`^(?i:(imdb|tmdb|tvdb)(?:id)?)[\-_:= ]?(tt\d{7,8}|\d{1,8})\s*[\]\}]`), regexp.MustCompile(`[\[\{]\s*$`)
+	imdb, imdblb := regexp.MustCompile(`^(tt\d{7,8})(?:$|[\]\)\}\-\._ ])`), regexp.MustCompile(`[\[\(\{\-\._ ]$`)
 	return TagLexer{
 		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			// database ids
+			if m := db.FindSubmatch(src[i:n]); m != nil && dblb.Match(src[:i]) {
+				return append(start, NewTag(TagTypeMeta, nil, m[0], bytes.ToLower(m[1]), m[2])), end, i + len(m[0]), n, true
+			}
+			if m := imdb.FindSubmatch(buf[i:n]); m != nil && imdblb.Match(src[:i]) {
+				return append(start, NewTag(TagTypeMeta, nil, src[i:i+len(m[1])], []byte("imdb"), m[1])), end, i + len(m[1]), n, true
+			}
 			// lookbehind
 			if lb.Match(src[:i]) {
 				if m := re.FindSubmatch(buf[i:n]); m != nil {
@@ -358,13 +343,400 @@ func NewEpisodeLexer() Lexer {
 	}
 }
 
+// NewFrameRateLexer creates a tag lexer for a frame rate (`23.976fps`,
+// `60FPS`, `50p`, `HFR`), including frame rates suffixed to a resolution
+// (`1080p50`).
+func NewFrameRateLexer() Lexer {
+	fps := regexp.MustCompile(`(?i)^(\d{2,3}(?:[\.,_]\d{1,3})?)[\-\._ ]?fps\b`)
+	p := regexp.MustCompile(`(?i)^(24|25|30|48|50|60)p\b`)
+	res := regexp.MustCompile(`(?i)^(\d{3,4}[pi])(\d{2,3}(?:\.\d{1,3})?)\b`)
+	var re *regexp.Regexp
+	var frameratef, resolutionf taginfo.FindFunc
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			framerate := infos["framerate"]
+			re, frameratef = regexp.MustCompile(reutil.Taginfo(`^ib`, framerate...)), taginfo.Find(framerate...)
+			resolutionf = taginfo.Find(infos["resolution"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			switch {
+			case re.Match(buf[i:n]):
+				m := re.FindSubmatch(buf[i:n])
+				return append(start, NewTag(TagTypeFrameRate, frameratef, append([][]byte{src[i : i+len(m[0])]}, m[1:]...)...)), end, i + len(m[0]), n, true
+			case fps.Match(src[i:n]):
+				m := fps.FindSubmatch(src[i:n])
+				return append(start, NewTag(TagTypeFrameRate, nil, m[0], m[1])), end, i + len(m[0]), n, true
+			case p.Match(buf[i:n]):
+				m := p.FindSubmatch(buf[i:n])
+				return append(start, NewTag(TagTypeFrameRate, nil, src[i:i+len(m[0])], m[1])), end, i + len(m[0]), n, true
+			case res.Match(buf[i:n]):
+				m := res.FindSubmatch(buf[i:n])
+				if resolutionf(string(m[1])) == nil {
+					break
+				}
+				v, rate := src[i:i+len(m[1])], src[i+len(m[1]):i+len(m[0])]
+				return append(
+					start,
+					NewTag(TagTypeResolution, resolutionf, v, v),
+					NewTag(TagTypeFrameRate, nil, rate, rate),
+				), end, i + len(m[0]), n, true
+			}
+			return start, end, i, n, false
+		},
+	}
+}
+
+// NewPixelFormatLexer creates a tag lexer for a pixel format (`yuv420p10`,
+// `yuv444p12le`), lexing the chroma subsampling and bit depth.
+func NewPixelFormatLexer() Lexer {
+	re := regexp.MustCompile(`(?i)^(yuv4[24][024]p)(8|10|12)(?:le)?\b`)
+	var bitdepthf, chromaf taginfo.FindFunc
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			bitdepthf, chromaf = taginfo.Find(infos["bitdepth"]...), taginfo.Find(infos["chroma"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			m := re.FindSubmatch(buf[i:n])
+			if m == nil {
+				return start, end, i, n, false
+			}
+			v, depth := src[i:i+len(m[1])], src[i+len(m[1]):i+len(m[0])]
+			return append(
+				start,
+				NewTag(TagTypeChroma, chromaf, v, v),
+				NewTag(TagTypeBitDepth, bitdepthf, depth, append(append([]byte(nil), m[2]...), "bit"...)),
+			), end, i + len(m[0]), n, true
+		},
+	}
+}
+
+// NewPlatformVersionLexer creates a tag lexer for a minimum operating system
+// version (`macOS.12+`, `Win10+`, `Android 8.0 or later`).
+func NewPlatformVersionLexer() Lexer {
+	re := regexp.MustCompile(`(?i)^(mac[\-\._ ]?os[\-\._ ]?x|osx|mac[\-\._ ]?os|ios|android|win(?:dows)?|linux)[\-\._ ]?(\d{1,2}(?:\.\d{1,2})?)(?:\+|[\-\._ ](?:or|and)[\-\._ ](?:later|newer|higher|above)\b)`)
+	delims := regexp.MustCompile(`[\-\._ ]`)
+	var platformf taginfo.FindFunc
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			platformf = taginfo.Find(infos["platform"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			m := re.FindSubmatch(src[i:n])
+			if m == nil {
+				return start, end, i, n, false
+			}
+			platform := []byte(platformNames[strings.ToLower(delims.ReplaceAllString(string(m[1]), ""))])
+			return append(start, NewTag(TagTypePlatform, platformf, src[i:i+len(m[0])], platform, m[2])), end, i + len(m[0]), n, true
+		},
+		NotFirst: true,
+	}
+}
+
+// platformNames are the platform names for minimum operating system
+// versions.
+var platformNames = map[string]string{
+	"macosx":  "MacOSX",
+	"osx":     "MacOSX",
+	"macos":   "MacOS",
+	"ios":     "iOS",
+	"android": "ANDROiD",
+	"win":     "Windows",
+	"windows": "Windows",
+	"linux":   "Linux",
+}
+
+// NewMusicQualityLexer creates a tag lexer for music quality markers, lexing
+// bit depth and sample rate pairs (`24-96`, `16-44.1`) as audio tags when
+// opening a bracketed block (`[24-96 FLAC]`), next to a lossless audio codec
+// (`FLAC 24-96`, `24-96 FLAC`), or wrapped in dashes (`-16-44-WEB-`). LAME
+// presets (`V0`, `APS`) and bare bitrates (`320`) are only lexed when
+// following a lossy audio codec (`MP3 V0`, `MP3 320`), with bare bitrates
+// also lexed when wrapped in dashes after a year, source, or audio tag
+// (`-2019-320-GRP`). The markers are only kept on music releases, and are not
+// collected as the release's audio.
+func NewMusicQualityLexer() Lexer {
+	rate := regexp.MustCompile(`^(16|24|32)([\-\._ ])(44(?:[\.,]1)?|48|88(?:[\.,]2)?|96|176(?:[\.,]4)?|192|352(?:[\.,]8)?|384)\b`)
+	preset := regexp.MustCompile(`(?i)^(v[0-2]|ap[sx])\b`)
+	bitrate := regexp.MustCompile(`^(320|256|224|192|160|128)\b`)
+	lossless := regexp.MustCompile(`(?i)^[\-\._ ]+(?:flac|alac)\b`)
+	var audiof taginfo.FindFunc
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			audiof = taginfo.Find(infos["audio"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			if m := rate.FindSubmatch(src[i:n]); m != nil {
+				if after := src[i+len(m[0]) : n]; bracketed(start) || losslessAudio(start) || lossless.Match(after) || string(m[2]) == "-" && dashed(start, after) {
+					depth := append(append([]byte(nil), m[1]...), "BIT"...)
+					hz := append(bytes.ReplaceAll(m[3], []byte(","), []byte(".")), "khz"...)
+					return append(
+						start,
+						NewTag(TagTypeAudio, audiof, m[1], depth),
+						NewTag(TagTypeDelim, nil, m[2], m[2]),
+						NewTag(TagTypeAudio, audiof, m[3], hz),
+					), end, i + len(m[0]), n, true
+				}
+			}
+			switch {
+			case lossyAudio(start) && preset.Match(src[i:n]):
+				m := preset.FindSubmatch(src[i:n])
+				return append(start, NewTag(TagTypeAudio, audiof, m[1], bytes.ToUpper(m[1]))), end, i + len(m[0]), n, true
+			case bitrate.Match(src[i:n]):
+				m := bitrate.FindSubmatch(src[i:n])
+				if !lossyAudio(start) && !(dashed(start, src[i+len(m[0]):n]) && prevIs(start, TagTypeDate, TagTypeSource, TagTypeAudio)) {
+					break
+				}
+				return append(start, NewTag(TagTypeAudio, audiof, m[1], append(append([]byte(nil), m[1]...), "Kbps"...))), end, i + len(m[0]), n, true
+			}
+			return start, end, i, n, false
+		},
+	}
+}
+
+// dashed returns true when the last tag is a dash delimiter, and after is
+// empty or starts with a dash.
+func dashed(start []Tag, after []byte) bool {
+	if len(start) == 0 || !start[len(start)-1].Is(TagTypeDelim) || start[len(start)-1].Delim() != "-" {
+		return false
+	}
+	return len(after) == 0 || after[0] == '-'
+}
+
+// prevIs returns true when the last non-delimiter tag is one of the types.
+func prevIs(start []Tag, types ...TagType) bool {
+	for i := len(start) - 1; i >= 0; i-- {
+		if !start[i].Is(TagTypeWhitespace, TagTypeDelim) {
+			return start[i].Is(types...)
+		}
+	}
+	return false
+}
+
+// bracketed returns true when the last tag is a delimiter opening a bracketed
+// block.
+func bracketed(start []Tag) bool {
+	if len(start) == 0 || !start[len(start)-1].Is(TagTypeDelim) {
+		return false
+	}
+	s := start[len(start)-1].Delim()
+	return strings.HasSuffix(s, "[") || strings.HasSuffix(s, "(")
+}
+
+// losslessAudio returns true when the last non-delimiter tag is a lossless
+// audio codec (FLAC).
+func losslessAudio(start []Tag) bool {
+	for i := len(start) - 1; i >= 0; i-- {
+		switch {
+		case start[i].Is(TagTypeWhitespace, TagTypeDelim):
+			continue
+		case start[i].Is(TagTypeAudio):
+			switch start[i].Audio() {
+			case "FLAC", "ALAC", "LOSSLESS":
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// lossyAudio returns true when the last non-delimiter tag is a lossy audio
+// codec or bitrate mode (MP3, VBR).
+func lossyAudio(start []Tag) bool {
+	for i := len(start) - 1; i >= 0; i-- {
+		switch {
+		case start[i].Is(TagTypeWhitespace, TagTypeDelim):
+			continue
+		case start[i].Is(TagTypeAudio):
+			switch start[i].Audio() {
+			case "AAC", "MP3", "OGG", "OPUS", "CBR", "VBR":
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// NewRoundLexer creates a tag lexer for sports rounds, weeks, matchdays
+// (`Week.05`, `Round05`, `Matchday 12`, `04.Spieltag`), and event numbers directly following
+// a league (`UFC.300`, `UFC.Fight.Night.240`). Only lexed after a league.
+func NewRoundLexer() Lexer {
+	delims := regexp.MustCompile(`[\-\._ ]`)
+	round := regexp.MustCompile(`(?i)^(round|rnd|week|wk|matchday|md|gameweek|gw|leg|stage|game|fight[\-\._ ]?night|event)[\-\._ ]?(\d{1,3})\b`)
+	ordinal := regexp.MustCompile(`(?i)^(\d{1,2})(?:st|nd|rd|th)?[\-\._ ]?(round|week|matchday|spieltag|jornada|giornata)\b`)
+	event := regexp.MustCompile(`^(\d{1,4})\b`)
+	year := regexp.MustCompile(`^(?:19|20)\d\d$`)
+	return TagLexer{
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			last, ok := league(start)
+			if !ok {
+				return start, end, i, n, false
+			}
+			if m := round.FindSubmatch(buf[i:n]); m != nil {
+				kind := roundKinds[strings.ToLower(delims.ReplaceAllString(string(m[1]), ""))]
+				return append(start, NewTag(TagTypeRound, nil, src[i:i+len(m[0])], []byte(kind), m[2])), end, i + len(m[0]), n, true
+			}
+			if m := ordinal.FindSubmatch(buf[i:n]); m != nil {
+				kind := roundKinds[strings.ToLower(string(m[2]))]
+				return append(start, NewTag(TagTypeRound, nil, src[i:i+len(m[0])], []byte(kind), m[1])), end, i + len(m[0]), n, true
+			}
+			if m := event.FindSubmatch(buf[i:n]); m != nil && !year.Match(m[1]) && last {
+				return append(start, NewTag(TagTypeRound, nil, src[i:i+len(m[0])], []byte("Event"), m[1])), end, i + len(m[0]), n, true
+			}
+			return start, end, i, n, false
+		},
+		NotFirst: true,
+	}
+}
+
+// roundKinds are the round kinds.
+var roundKinds = map[string]string{
+	"round":      "Round",
+	"rnd":        "Round",
+	"week":       "Week",
+	"wk":         "Week",
+	"matchday":   "Matchday",
+	"md":         "Matchday",
+	"gameweek":   "Week",
+	"gw":         "Week",
+	"leg":        "Leg",
+	"stage":      "Stage",
+	"game":       "Game",
+	"fightnight": "Fight Night",
+	"event":      "Event",
+	"spieltag":   "Matchday",
+	"jornada":    "Matchday",
+	"giornata":   "Matchday",
+}
+
+// league returns true when start contains a league, and whether the league
+// is the last non-delimiter tag.
+func league(start []Tag) (bool, bool) {
+	last := true
+	for i := len(start) - 1; i >= 0; i-- {
+		switch {
+		case start[i].Is(TagTypeLeague):
+			return last, true
+		case !start[i].Is(TagTypeWhitespace, TagTypeDelim):
+			last = false
+		}
+	}
+	return false, false
+}
+
+// NewISBNLexer creates a tag lexer for an ISBN-10 or ISBN-13, either prefixed
+// (`ISBN 0-306-40615-2`, `ISBN-13:978-0-306-40615-7`) or as a bare ISBN-13
+// (`9780306406157`). Only ISBNs with a valid checksum are lexed.
+func NewISBNLexer() Lexer {
+	prefixed := regexp.MustCompile(`(?i)^isbn(?:[\-_ ]?1[03])?[:\-\._ ]*(\d[\d\- ]{8,15}[\dX])\b`)
+	bare := regexp.MustCompile(`^(97[89](?:-?\d){10})\b`)
+	return TagLexer{
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			m := prefixed.FindSubmatch(src[i:n])
+			if m == nil {
+				m = bare.FindSubmatch(src[i:n])
+			}
+			if m != nil {
+				if tag := NewTag(TagTypeISBN, nil, src[i:i+len(m[0])], m[1]); ValidISBN(tag.ISBN()) {
+					return append(start, tag), end, i + len(m[0]), n, true
+				}
+			}
+			return start, end, i, n, false
+		},
+	}
+}
+
+// ValidISBN returns true when s is an ISBN-10 or ISBN-13 with a valid
+// checksum.
+func ValidISBN(s string) bool {
+	var sum int
+	switch len(s) {
+	case 10:
+		for i, c := range s {
+			d := int(c - '0')
+			switch {
+			case c == 'X' && i == 9:
+				d = 10
+			case c < '0' || '9' < c:
+				return false
+			}
+			sum += (10 - i) * d
+		}
+		return sum%11 == 0
+	case 13:
+		for i, c := range s {
+			if c < '0' || '9' < c {
+				return false
+			}
+			sum += int(c-'0') * (1 + 2*(i%2))
+		}
+		return sum%10 == 0
+	}
+	return false
+}
+
+// ValidIMDbID returns true when s is an IMDb title ID (`tt` followed by 7 or 8
+// digits).
+func ValidIMDbID(s string) bool {
+	if len(s) != 9 && len(s) != 10 || !strings.HasPrefix(s, "tt") {
+		return false
+	}
+	for _, c := range s[2:] {
+		if c < '0' || '9' < c {
+			return false
+		}
+	}
+	return s[2:] != strings.Repeat("0", len(s)-2)
+}
+
+// validDBID returns true when s is a positive TMDb or TVDb ID.
+func validDBID(s string) bool {
+	id, err := strconv.Atoi(s)
+	return err == nil && 0 < id
+}
+
+// NewAnimeLexer creates a tag lexer for anime episode ranges (`- 01-12 [`,
+// `- 01 ~ 24 (`) and batch markers (`[Batch]`).
+func NewAnimeLexer() Lexer {
+	re := regexp.MustCompile(`^(\d{1,4})(?:v\d)?[\._ ]?[\-~][\._ ]?(\d{1,4})(?:v\d)?(\b|[\._ ]?[\[\]\(\)\{\}])`)
+	// lookbehinds only match the end of src, up to the max lookbehind length
+	lb := regexp.MustCompile(`-[\-\._ ]{1,3}$`)
+	batch, blb := regexp.MustCompile(`(?i)^batch\b`), regexp.MustCompile(`[\[\(]$`)
+	return TagLexer{
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			switch {
+			case blb.Match(src[max(0, i-1):i]):
+				if m := batch.Find(buf[i:n]); m != nil {
+					v := src[i : i+len(m)]
+					return append(start, NewTag(TagTypeMeta, nil, v, []byte("batch"), v)), end, i + len(m), n, true
+				}
+			case lb.Match(src[max(0, i-4):i]):
+				if m := re.FindSubmatch(src[i:n]); m != nil {
+					first, _ := strconv.Atoi(string(m[1]))
+					last, _ := strconv.Atoi(string(m[2]))
+					if first < last {
+						l := len(m[0]) - len(m[3])
+						tags := []Tag{NewTag(TagTypeSeries, nil, src[i:i+l], nil, m[1], m[2])}
+						if len(m[3]) != 0 {
+							tags = append(tags, NewTag(TagTypeDelim, nil, m[3], m[3]))
+						}
+						return append(start, tags...), end, i + len(m[0]), n, true
+					}
+				}
+			}
+			return start, end, i, n, false
+		},
+	}
+}
+
 // NewVersionLexer creates a tag lexer for a version.
 func NewVersionLexer(strs ...string) Lexer {
 	lexer := NamedCaptureLexer(strs...)
 	return TagLexer{
 		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
 			if s, v, i, n, ok := lexer(src, buf, i, n); ok {
-				var version []byte
+				var version, build []byte
 				for l := 0; l < len(v); l += 2 {
 					switch string(v[l]) {
 					case "v":
@@ -377,10 +749,15 @@ func NewVersionLexer(strs ...string) Lexer {
 						version = v[l+1]
 					case "u":
 						version = append([]byte{'v'}, v[l+1]...)
+					case "b":
+						build = v[l+1]
 					default:
 						panic(fmt.Errorf("unknown capture group %q", v[l]))
 					}
 				}
+				if build != nil {
+					return append(start, NewTag(TagTypeVersion, nil, s, version, build)), end, i, n, true
+				}
 				return append(start, NewTag(TagTypeVersion, nil, s, version)), end, i, n, true
 			}
 			return start, end, i, n, false
@@ -444,11 +821,13 @@ func NewDiscLexer(strs ...string) Lexer {
 		},
 		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
 			if s, v, j, k, ok := lexer(src, buf, i, n); ok {
-				var typ, c, x, z []byte
+				var typ, c, x, z, total []byte
 				for l := 0; l < len(v); l += 2 {
 					switch string(v[l]) {
 					case "c":
 						c = v[l+1]
+					case "n":
+						total = v[l+1]
 					case "t":
 						typ = bytes.ToUpper(v[l+1])
 					case "x":
@@ -460,15 +839,23 @@ func NewDiscLexer(strs ...string) Lexer {
 					}
 				}
 				if len(typ) != 0 {
-					typ = bytes.ToUpper(typ[:len(re.FindSubmatch(s)[0])])
+					m := re.FindSubmatch(s)
+					if m == nil || len(typ) < len(m[0]) {
+						return start, end, i, n, false
+					}
+					typ = bytes.ToUpper(typ[:len(m[0])])
+				}
+				disc := [][]byte{typ, c}
+				if len(total) != 0 {
+					disc = append(disc, total)
 				}
 				switch string(typ) {
 				case "D", "S":
-					return append(start, NewTag(TagTypeDisc, nil, s, typ, c)), end, j, k, true
+					return append(start, NewTag(TagTypeDisc, nil, append([][]byte{s}, disc...)...)), end, j, k, true
 				case "DVDA", "DVD", "CD":
 					return append(start,
 						NewTag(TagTypeSource, sourcef, s[:len(typ)], typ, typ),
-						NewTag(TagTypeDisc, nil, s[len(typ):], typ, c),
+						NewTag(TagTypeDisc, nil, append([][]byte{s[len(typ):]}, disc...)...),
 					), end, j, k, true
 				case "X":
 					if sz := strings.ToUpper(string(s[len(c)+1:])); sz == "DVD9" {
@@ -491,16 +878,123 @@ func NewDiscLexer(strs ...string) Lexer {
 						NewTag(TagTypeDisc, nil, s[:len(c)+1], []byte{'X'}, c),
 						NewTag(TagTypeSource, sourcef, s[len(c)+1:], x),
 					), end, j, k, true
-				default:
-					panic(fmt.Errorf("unknown type %q", typ))
 				}
+				return start, end, i, n, false
 			}
 			return start, end, i, n, false
 		},
 	}
 }
 
-// NewAudioLexer creates a tag lexer for audios.
+// NewPartLexer creates a tag lexer for parts (`Part.1`, `Pt2`, `Part.III`,
+// `Part.One`, `Part.2.of.3`, `1of6`). Roman numerals and spelled out numbers
+// are converted to their numeric value.
+func NewPartLexer() Lexer {
+	num := `(\d{1,2}|[ivx]{1,5}|one|two|three|four|five|six|seven|eight|nine|ten)`
+	part := regexp.MustCompile(`(?i)^(?:part|pt)[\-\._ ]?` + num + `(?:[\-\._ ]?of[\-\._ ]?` + num + `)?\b`)
+	of := regexp.MustCompile(`(?i)^(\d{1,2})[\-\._ ]?of[\-\._ ]?(\d{1,2})\b`)
+	return TagLexer{
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			m := part.FindSubmatch(buf[i:n])
+			if m == nil {
+				m = of.FindSubmatch(buf[i:n])
+			}
+			if m == nil {
+				return start, end, i, n, false
+			}
+			p, total := partNumber(string(m[1])), partNumber(string(m[2]))
+			switch {
+			case p == 0,
+				len(m[2]) != 0 && (total < 2 || total < p),
+				len(m[2]) == 0 && 20 < p:
+				return start, end, i, n, false
+			}
+			var v []byte
+			if total != 0 {
+				v = []byte(strconv.Itoa(total))
+			}
+			return append(start, NewTag(TagTypePart, nil, src[i:i+len(m[0])], []byte(strconv.Itoa(p)), v)), end, i + len(m[0]), n, true
+		},
+		NotFirst: true,
+	}
+}
+
+// partNumbers are spelled out part numbers.
+var partNumbers = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"ten":   10,
+}
+
+// partNumber converts a part number, roman numeral, or spelled out number,
+// returning 0 when s is not a number.
+func partNumber(s string) int {
+	s = strings.ToLower(s)
+	if i, ok := partNumbers[s]; ok {
+		return i
+	}
+	if i, _, ok := convNumber(s); ok {
+		return i
+	}
+	return 0
+}
+
+// NewThreeDLexer creates a tag lexer for 3D layouts, lexing full/half
+// side-by-side and over-under layouts (`H-SBS`, `Half-OU`, `Full.TAB`) and
+// anaglyphs as other tags. Bare layouts (`SBS`, `OU`, `TAB`, `MVC`) are only
+// lexed when following a 3D marker (`3D.OU`, `3D.BluRay.MVC`).
+func NewThreeDLexer() Lexer {
+	re := regexp.MustCompile(`(?i)^(?:(full|half|f|h)[\-\._ ]?)?(sbs|ou|tab|mvc|anaglyph)\b`)
+	var otherf taginfo.FindFunc
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			otherf = taginfo.Find(infos["other"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			m := re.FindSubmatch(buf[i:n])
+			if m == nil {
+				return start, end, i, n, false
+			}
+			prefix, layout := strings.ToLower(string(m[1])), strings.ToUpper(string(m[2]))
+			switch {
+			case prefix != "" && (layout == "MVC" || layout == "ANAGLYPH"),
+				prefix == "" && layout != "ANAGLYPH" && !threeD(start):
+				return start, end, i, n, false
+			case layout == "ANAGLYPH":
+				layout = "Anaglyph"
+			case layout == "TAB":
+				layout = "OU"
+			}
+			if prefix == "half" || prefix == "h" {
+				layout = "Half-" + layout
+			}
+			return append(start, NewTag(TagTypeOther, otherf, src[i:i+len(m[0])], []byte(layout))), end, i + len(m[0]), n, true
+		},
+	}
+}
+
+// threeD returns true when a 3D marker (`3D`, `BluRay3D`) was previously
+// lexed.
+func threeD(start []Tag) bool {
+	for i := len(start) - 1; i >= 0; i-- {
+		if start[i].Is(TagTypeOther, TagTypeSource) && start[i].InfoKind() == "3d" {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAudioLexer creates a tag lexer for audios, lexing an audio codec and its
+// directly following channels (`DDP5.1`, `DTS-HD.MA.7.1`).
+//
+// See AudioTracks for grouping the lexed tags into audio tracks.
 func NewAudioLexer() Lexer {
 	var re *regexp.Regexp
 	var audiof, channelsf taginfo.FindFunc
@@ -531,6 +1025,30 @@ func NewAudioLexer() Lexer {
 	}
 }
 
+// NewLabelLexer creates a tag lexer for a bracketed music label (`(Ninja
+// Tune)`, `[Warp Records]`), lexed as a label meta tag.
+func NewLabelLexer() Lexer {
+	var labelf taginfo.FindFunc
+	var re, lb *regexp.Regexp
+	return TagLexer{
+		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
+			var v []string
+			for _, info := range infos["label"] {
+				v = append(v, info.RE())
+			}
+			re, lb, labelf = regexp.MustCompile(`(?i)^(`+strings.Join(v, `|`)+`)\s*[\)\]]`), regexp.MustCompile(`[\(\[]\s*$`), taginfo.Find(infos["label"]...)
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			if m := re.FindSubmatch(src[i:n]); m != nil && lb.Match(src[:i]) {
+				if info := labelf(string(m[1])); info != nil {
+					return append(start, NewTag(TagTypeMeta, nil, m[1], []byte("label"), []byte(info.Tag()))), end, i + len(m[1]), n, true
+				}
+			}
+			return start, end, i, n, false
+		},
+	}
+}
+
 // NewGenreLexer creates a tag lexer for a genre.
 func NewGenreLexer() Lexer {
 	var genref taginfo.FindFunc
@@ -570,13 +1088,17 @@ func NewGenreLexer() Lexer {
 
 // NewGroupLexer creates a tag lexer for a group.
 func NewGroupLexer() Lexer {
-	const delim, invalid = '-', ` _.()[]{}+`
+	const delim = '-'
 	year, group := regexp.MustCompile(`\b(19|20)\d{2}\b`), regexp.MustCompile(`(?i)^[a-z_ ]{2,10}$`)
 	bracket := regexp.MustCompile(`^[\]\)\}]`)
+	invalid := DefaultDelims().Group
 	var groupf, otherf taginfo.FindFunc
 	var re, special *regexp.Regexp
 	var shortTags map[string]bool
 	return TagLexer{
+		Delims: func(delims Delims) {
+			invalid = delims.Group
+		},
 		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, short map[string]bool) {
 			var v []string
 			group, other := infos["group"], infos["other"]
@@ -661,7 +1183,7 @@ func NewMetaLexer(strs ...string) Lexer {
 						if k, v = strs[l*4], m[1]; hasTwo[l] {
 							k, v = string(m[1]), m[2]
 						}
-						short = len(strs[l*4+1]) == 1 && shortTags[strings.ToUpper(string(v))]
+						short = len(strs[l*4+1]) == 1 && shortTags[strings.ToUpper(metaContents(m[0], strs[l*4+1], strs[l*4+2]))]
 						matched, prev[k] = !prev[k] && !short && !bytes.ContainsAny(v, "\t\r\n\f +"), true
 					}
 				}
@@ -689,7 +1211,7 @@ func NewMetaLexer(strs ...string) Lexer {
 						if k, v = strs[l*4], m[1]; hasTwo[l] {
 							k, v = string(m[1]), m[2]
 						}
-						short = len(strs[l*4+1]) == 1 && shortTags[strings.ToUpper(string(v))]
+						short = len(strs[l*4+1]) == 1 && shortTags[strings.ToUpper(metaContents(m[0], strs[l*4+1], strs[l*4+2]))]
 						matched, prev[k] = !prev[k] && !short && !bytes.ContainsAny(v, "\t\r\n\f +"), true
 					}
 				}
@@ -715,6 +1237,12 @@ func NewMetaLexer(strs ...string) Lexer {
 	}
 }
 
+// metaContents returns the trimmed contents of a bracketed meta tag.
+func metaContents(b []byte, open, close string) string {
+	s := strings.TrimSpace(string(b))
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, open), close))
+}
+
 // NewExtLexer creates a tag lexer for a file's extension.
 func NewExtLexer() Lexer {
 	var extf taginfo.FindFunc
@@ -740,7 +1268,7 @@ func NewRegexpLexer(typ TagType, ignoreCase bool) TagLexer {
 	var re *regexp.Regexp
 	return TagLexer{
 		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
-			info := infos[strings.ToLower(typ.String())]
+			info := infos[strings.ToLower(typ.Name())]
 			s := `^ib`
 			if !ignoreCase {
 				s = `^b`
@@ -762,7 +1290,7 @@ func NewRegexpSourceLexer(typ TagType, ignoreCase bool) TagLexer {
 	var re *regexp.Regexp
 	return TagLexer{
 		Init: func(infos map[string][]*taginfo.Taginfo, _ *regexp.Regexp, _ map[string]bool) {
-			info := infos[strings.ToLower(typ.String())]
+			info := infos[strings.ToLower(typ.Name())]
 			s := `^i`
 			if !ignoreCase {
 				s = `^`
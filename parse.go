@@ -17,6 +17,8 @@ import (
 // TagParser is a release tag parser.
 type TagParser struct {
 	builder  Builder
+	delims   Delims
+	anime    bool
 	delim    *regexp.Regexp
 	ellip    []byte
 	work     *regexp.Regexp
@@ -27,14 +29,39 @@ type TagParser struct {
 
 // NewTagParser creates a new release tag parser.
 func NewTagParser(infos map[string][]*taginfo.Taginfo, lexers ...Lexer) Parser {
+	return NewTagParserWithOptions(infos, lexers)
+}
+
+// NewTagParserWithOptions creates a new release tag parser using the tag
+// parser options.
+func NewTagParserWithOptions(infos map[string][]*taginfo.Taginfo, lexers []Lexer, opts ...TagParserOption) Parser {
+	p := &TagParser{
+		builder: DefaultBuilder,
+		delims:  DefaultDelims(),
+		ellip:   []byte("..."),
+	}
+	for _, o := range opts {
+		o(p)
+	}
 	// delims
-	var v []string
-	for r := rune(0); r < 255; r++ {
-		if isAnyDelim(r) {
-			v = append(v, string(r))
+	var v, w []string
+	for _, r := range p.delims.Any {
+		v = append(v, string(r))
+	}
+	for _, r := range p.delims.Work {
+		w = append(w, string(r))
+	}
+	// extra delimiters are replaced in the working buf, for the lexers
+	def := DefaultDelims()
+	for _, r := range p.delims.Any {
+		if !def.IsAny(r) && !strings.ContainsRune(p.delims.Work, r) {
+			w = append(w, string(r))
 		}
 	}
-	delim := regexp.MustCompile(`^((?:` + reutil.Join(true, v...) + ")+)")
+	p.delim = regexp.MustCompile(`^((?:` + reutil.Join(true, v...) + ")+)")
+	if len(w) != 0 {
+		p.work = regexp.MustCompile(reutil.Join(true, w...))
+	}
 	// build short tags
 	short := make(map[string]bool)
 	hdr := strings.ToLower(TagTypeHDR.String())
@@ -43,40 +70,50 @@ func NewTagParser(infos map[string][]*taginfo.Taginfo, lexers ...Lexer) Parser {
 			continue
 		}
 		for _, info := range v {
-			for _, field := range strings.FieldsFunc(info.Tag(), isAnyDelim) {
+			for _, field := range strings.FieldsFunc(info.Tag(), p.delims.IsAny) {
 				if len(field) < 5 && !strings.Contains(field, "$") {
 					short[strings.ToUpper(field)] = true
 				}
 			}
 		}
 	}
+	// anime
+	if p.anime {
+		lexers = append([]Lexer{NewAnimeLexer()}, lexers...)
+	}
 	// separate once and multi
-	var once, multi []LexFunc
-	var notFirst []bool
 	for _, lexer := range lexers {
-		if f, o, nf := lexer.Initialize(infos, delim, short); o {
-			once = append(once, f)
+		if l, ok := lexer.(interface {
+			SetDelims(Delims)
+		}); ok {
+			l.SetDelims(p.delims)
+		}
+		if f, o, nf := lexer.Initialize(infos, p.delim, short); o {
+			p.once = append(p.once, f)
 		} else {
-			multi = append(multi, f)
-			notFirst = append(notFirst, nf)
+			p.multi = append(p.multi, f)
+			p.notFirst = append(p.notFirst, nf)
 		}
 	}
 	// init builder
-	builder := DefaultBuilder
-	if b, ok := builder.(interface {
+	if b, ok := p.builder.(interface {
 		Init(map[string][]*taginfo.Taginfo) Builder
 	}); ok {
-		builder = b.Init(infos)
+		p.builder = b.Init(infos)
 	}
-	return &TagParser{
-		builder:  builder,
-		delim:    delim,
-		ellip:    []byte("..."),
-		work:     regexp.MustCompile(`[_,\+]`),
-		once:     once,
-		multi:    multi,
-		notFirst: notFirst,
+	if b, ok := p.builder.(interface {
+		SetDelims(Delims)
+	}); ok {
+		b.SetDelims(p.delims)
+	}
+	if b, ok := p.builder.(interface {
+		InsertStage(string, Stage) error
+	}); ok && p.anime {
+		if err := b.InsertStage("titles", AnimeStage()); err != nil {
+			_ = b.InsertStage("", AnimeStage())
+		}
 	}
+	return p
 }
 
 // SetBuilder sets the builder for the tag parser.
@@ -84,10 +121,45 @@ func (p *TagParser) SetBuilder(builder Builder) {
 	p.builder = builder
 }
 
+// TagParserOption is a tag parser option.
+type TagParserOption func(*TagParser)
+
+// WithDelims is a tag parser option to set the delimiters used by the tag
+// parser, its lexers, and builder.
+func WithDelims(delims Delims) TagParserOption {
+	return func(p *TagParser) {
+		p.delims = delims
+	}
+}
+
+// WithBuilder is a tag parser option to set the builder used by the tag
+// parser. The builder is initialized with the tag parser's infos and
+// delimiters.
+func WithBuilder(builder Builder) TagParserOption {
+	return func(p *TagParser) {
+		p.builder = builder
+	}
+}
+
+// WithAnime is a tag parser option to enable anime mode, reading fansub
+// groups, absolute episodes, episode ranges, and batch markers.
+//
+// See NewAnimeLexer and AnimeStage.
+func WithAnime() TagParserOption {
+	return func(p *TagParser) {
+		p.anime = true
+	}
+}
+
 // Parse parses tags in buf.
 func (p *TagParser) Parse(src []byte) ([]Tag, int) {
 	// working buf
-	buf := p.work.ReplaceAll(src, []byte{' '})
+	buf := src
+	if p.work != nil {
+		buf = p.work.ReplaceAllFunc(src, func(b []byte) []byte {
+			return bytes.Repeat([]byte{' '}, len(b))
+		})
+	}
 	i, n := 0, len(buf)
 	var start, end []Tag
 	// once
@@ -96,6 +168,9 @@ func (p *TagParser) Parse(src []byte) ([]Tag, int) {
 	}
 	// consume
 	notFirst := false
+	for _, tag := range start {
+		notFirst = notFirst || tag.Is(TagTypeText)
+	}
 	for i < n {
 		start, end, i, _ = p.next(src, buf, start, end, i, n, &notFirst)
 	}
@@ -180,27 +255,59 @@ type TagBuilder struct {
 	digpre *regexp.Regexp
 	// digsuf matches digit suffixes.
 	digsuf *regexp.Regexp
+	// catalog matches a music catalog number.
+	catalog *regexp.Regexp
+	// narrator matches an audiobook narrator.
+	narrator *regexp.Regexp
+	// issue matches a prefixed issue number.
+	issue *regexp.Regexp
+	// versus matches a versus separator.
+	versus *regexp.Regexp
+	// included matches a bundled content prefix.
+	included *regexp.Regexp
+	// dated matches a date version (v20230101).
+	dated *regexp.Regexp
 	// infos are tag info.
 	infos map[string][]*taginfo.Taginfo
 	// containerf is the container find func.
 	containerf taginfo.FindFunc
 	// audiof is the audio find func.
 	audiof taginfo.FindFunc
+	// sourcef is the source find func.
+	sourcef taginfo.FindFunc
+	// otherf is the other find func.
+	otherf taginfo.FindFunc
+	// labelf is the label find func.
+	labelf taginfo.FindFunc
+	// publisherf is the book publisher find func.
+	publisherf taginfo.FindFunc
+	// delims are the delimiters.
+	delims Delims
+	// stages are the build stages.
+	stages []Stage
 }
 
 // NewTagBuilder creates a new release builder.
 func NewTagBuilder() *TagBuilder {
 	return &TagBuilder{
-		missing: regexp.MustCompile(`\b[A-Z][\. ][A-Z](?:[\. ][A-Z])*[\. ]?\b`),
-		bad:     regexp.MustCompile(`[^A-Z][-\. ][A-Z]\.($|[^A-Z])`),
-		fix:     regexp.MustCompile(`([A-Z])\.`),
-		spaces:  regexp.MustCompile(`\s+`),
-		ellips:  regexp.MustCompile(`\.{3,}`),
-		plus:    regexp.MustCompile(`(\+)`),
-		sum:     regexp.MustCompile(`(?i)^[a-f0-9]{8}$`),
-		digits:  regexp.MustCompile(`^\d+$`),
-		digpre:  regexp.MustCompile(`^\d+`),
-		digsuf:  regexp.MustCompile(`\d+$`),
+		missing:  regexp.MustCompile(`\b[A-Z][\. ][A-Z](?:[\. ][A-Z])*[\. ]?\b`),
+		bad:      regexp.MustCompile(`[^A-Z][-\. ][A-Z]\.($|[^A-Z])`),
+		fix:      regexp.MustCompile(`([A-Z])\.`),
+		spaces:   regexp.MustCompile(`\s+`),
+		ellips:   regexp.MustCompile(`\.{3,}`),
+		plus:     regexp.MustCompile(`(\+)`),
+		sum:      regexp.MustCompile(`(?i)^[a-f0-9]{8}$`),
+		digits:   regexp.MustCompile(`^\d+$`),
+		digpre:   regexp.MustCompile(`^\d+`),
+		digsuf:   regexp.MustCompile(`\d+$`),
+		catalog:  regexp.MustCompile(`^(?:[A-Z]{2,8}[\-_ ]?\d{2,8}[A-Z]{0,4}(?:[\-_ ](?:[A-Z]{1,8}\d{1,8}|\d{1,4}|[A-Z]{1,4}))?|\d{12,13})$`),
+		narrator: regexp.MustCompile(`(?i)(?:^|\b)(?:read|narrated)[ ]by[ ](.+)$`),
+		issue:    regexp.MustCompile(`(?i)^(?:#|n)\d{1,5}$`),
+		versus:   regexp.MustCompile(`(?i)\s+(?:vs?\.?|versus)\s+`),
+		included: regexp.MustCompile(`(?i)^(?:incl?|including|plus|and)[\-\._ ]?[a-z0-9]`),
+		dated:    regexp.MustCompile(`^v(?:19|20)\d\d(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])$`),
+		delims:   DefaultDelims(),
+		stages:   DefaultStages(),
 	}
 }
 
@@ -217,38 +324,207 @@ func (b *TagBuilder) Init(infos map[string][]*taginfo.Taginfo) Builder {
 		digits:     b.digits,
 		digpre:     b.digpre,
 		digsuf:     b.digsuf,
+		catalog:    b.catalog,
+		narrator:   b.narrator,
+		issue:      b.issue,
+		versus:     b.versus,
+		included:   b.included,
+		dated:      b.dated,
 		infos:      infos,
 		containerf: taginfo.Find(infos["container"]...),
 		audiof:     taginfo.Find(infos["audio"]...),
+		sourcef:    taginfo.Find(infos["source"]...),
+		otherf:     taginfo.Find(infos["other"]...),
+		labelf:     taginfo.Find(infos["label"]...),
+		publisherf: taginfo.Find(infos["publisher"]...),
+		delims:     b.delims,
+		stages:     append([]Stage(nil), b.stages...),
 	}
 }
 
+// SetDelims sets the delimiters used by the builder.
+func (b *TagBuilder) SetDelims(delims Delims) {
+	b.delims = delims
+}
+
 // Build builds a release from tags.
 func (b *TagBuilder) Build(tags []Tag, end int) Release {
 	r := &Release{
 		tags: tags,
 		end:  end,
 	}
-	// initialize / fix tags
-	b.init(r)
-	// collect tags into release
-	b.collect(r)
-	// guess type
-	r.Type = b.inspect(r, true)
-	// special
-	b.specialDate(r)
-	// unset tags
-	b.unset(r)
-	// read titles
-	i := b.titles(r)
-	// demarcate unused
-	b.unused(r, i)
+	for _, stage := range b.stages {
+		stage.Func(b, r)
+	}
 	return *r
 }
 
-// init fixes the initial tag set.
-func (b *TagBuilder) init(r *Release) {
-	b.fixFirstDate(r)
+// Stage is a named release build stage.
+type Stage struct {
+	Name string
+	Func func(*TagBuilder, *Release)
+}
+
+// DefaultStages returns the default release build stages, in the order they
+// are run by the tag builder.
+func DefaultStages() []Stage {
+	return []Stage{
+		// initialize / fix tags
+		{"fixFirstDate", (*TagBuilder).fixFirstDate},
+		{"pivot", (*TagBuilder).pivot},
+		{"fixFirst", (*TagBuilder).fixFirst},
+		{"fixBad", (*TagBuilder).fixBad},
+		{"fixNoText", (*TagBuilder).fixNoText},
+		{"fixIsolated", (*TagBuilder).fixIsolated},
+		{"fixMusic", (*TagBuilder).fixMusic},
+		// collect tags into release
+		{"collect", (*TagBuilder).collect},
+		// guess type
+		{"inspect", func(b *TagBuilder, r *Release) {
+			r.Type = b.inspect(r, true)
+		}},
+		// audio and subtitle languages
+		{"languages", (*TagBuilder).languages},
+		// music quality
+		{"music", (*TagBuilder).music},
+		// game details
+		{"game", (*TagBuilder).game},
+		// special
+		{"specialDate", (*TagBuilder).specialDate},
+		// comic and magazine issues
+		{"issues", (*TagBuilder).issues},
+		// unset tags
+		{"unset", (*TagBuilder).unset},
+		// 3d layout
+		{"threeD", (*TagBuilder).threeD},
+		// scene/p2p/internal origin
+		{"origin", (*TagBuilder).origin},
+		// read titles
+		{"titles", func(b *TagBuilder, r *Release) {
+			r.last = b.titles(r)
+		}},
+		// demarcate unused
+		{"unused", func(b *TagBuilder, r *Release) {
+			b.unused(r, r.last)
+		}},
+	}
+}
+
+// Stages returns a copy of the builder's stages.
+func (b *TagBuilder) Stages() []Stage {
+	return append([]Stage(nil), b.stages...)
+}
+
+// SetStages sets the builder's stages.
+func (b *TagBuilder) SetStages(stages ...Stage) {
+	b.stages = append([]Stage(nil), stages...)
+}
+
+// InsertStage inserts stage before the stage with name. When name is empty,
+// the stage is appended.
+func (b *TagBuilder) InsertStage(name string, stage Stage) error {
+	if name == "" {
+		b.stages = append(b.stages, stage)
+		return nil
+	}
+	i := b.stageIndex(name)
+	if i == -1 {
+		return fmt.Errorf("unknown stage %q", name)
+	}
+	b.stages = append(b.stages[:i], append([]Stage{stage}, b.stages[i:]...)...)
+	return nil
+}
+
+// InsertStageAfter inserts stage after the stage with name.
+func (b *TagBuilder) InsertStageAfter(name string, stage Stage) error {
+	i := b.stageIndex(name)
+	if i == -1 {
+		return fmt.Errorf("unknown stage %q", name)
+	}
+	b.stages = append(b.stages[:i+1], append([]Stage{stage}, b.stages[i+1:]...)...)
+	return nil
+}
+
+// ReplaceStage replaces the func of the stage with name.
+func (b *TagBuilder) ReplaceStage(name string, f func(*TagBuilder, *Release)) error {
+	i := b.stageIndex(name)
+	if i == -1 {
+		return fmt.Errorf("unknown stage %q", name)
+	}
+	b.stages[i].Func = f
+	return nil
+}
+
+// RemoveStage removes the stage with name.
+func (b *TagBuilder) RemoveStage(name string) error {
+	i := b.stageIndex(name)
+	if i == -1 {
+		return fmt.Errorf("unknown stage %q", name)
+	}
+	b.stages = append(b.stages[:i], b.stages[i+1:]...)
+	return nil
+}
+
+// stageIndex returns the index of the stage with name.
+func (b *TagBuilder) stageIndex(name string) int {
+	for i, stage := range b.stages {
+		if stage.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// AnimeStage returns the anime build stage. Moves a leading site to the group,
+// moves the episode (or episode range) to the absolute episode when there is
+// no series, and marks batches.
+func AnimeStage() Stage {
+	return Stage{"anime", (*TagBuilder).anime}
+}
+
+// anime fixes anime releases.
+func (b *TagBuilder) anime(r *Release) {
+	// leading fansub group
+	i := 0
+	for ; i < len(r.tags) && r.tags[i].Is(TagTypeWhitespace); i++ {
+	}
+	if i < len(r.tags) && r.tags[i].Is(TagTypeMeta) && r.Group == "" {
+		if k, v := r.tags[i].Meta(); k == "site" && v == r.Site {
+			r.Group, r.Site = v, ""
+			r.tags[i] = NewTag(TagTypeGroup, nil, []byte(r.tags[i].v[0]), []byte(v))
+		}
+	}
+	// batch marker
+	for j := 0; j < len(r.Meta); j++ {
+		if strings.HasPrefix(r.Meta[j], "batch:") {
+			r.Batch, r.Meta = true, append(r.Meta[:j], r.Meta[j+1:]...)
+			j--
+		}
+	}
+	// absolute episodes
+	if r.Series != 0 || r.Episode == 0 {
+		return
+	}
+	for _, tag := range r.tags {
+		if !tag.Is(TagTypeSeries) {
+			continue
+		}
+		if eps := tag.Episodes(); len(eps) != 0 {
+			r.AbsoluteEpisode, r.Episode = eps[0], 0
+			if len(eps) > 1 {
+				r.AbsoluteEpisodeEnd, r.Batch = eps[len(eps)-1], true
+			}
+		}
+		break
+	}
+	if r.Batch && r.Type == Episode {
+		r.Type = Series
+	}
+}
+
+// pivot determines the earliest pivot tag, collecting dates, fixing special
+// tags, and resetting language/arch/platform tags prior to it.
+func (b *TagBuilder) pivot(r *Release) {
 	// determine earliest pivot
 	m, pivot := b.pivots(r, TagTypeDate, TagTypeSource, TagTypeSeries, TagTypeResolution, TagTypeVersion)
 	date, series := m[TagTypeDate], m[TagTypeSeries]
@@ -271,15 +547,9 @@ func (b *TagBuilder) init(r *Release) {
 		b.fixSpecial(r, i, series != -1)
 	}
 	// get first text prior to pivot
-	end := b.end(r, pivot)
+	r.pivot = b.end(r, pivot)
 	// reset language/other/arch/platform prior to end
-	_ = b.reset(r, end, TagTypeLanguage, TagTypeArch, TagTypePlatform)
-	b.fixFirst(r)
-	start := b.start(r, 0)
-	b.fixBad(r, start, end)
-	b.fixNoText(r, end)
-	b.fixIsolated(r)
-	b.fixMusic(r)
+	_ = b.reset(r, r.pivot, TagTypeLanguage, TagTypeArch, TagTypePlatform)
 }
 
 // fixFirstDate fixes the special case of a date occuring before text, and
@@ -372,13 +642,18 @@ func (b *TagBuilder) fixFirst(r *Release) {
 		TagTypeEdition,
 		TagTypeLanguage,
 		TagTypeRegion,
+		TagTypeBitDepth,
+		TagTypeChroma,
+		TagTypeFrameRate,
 	) {
 		r.tags[i] = r.tags[i].As(TagTypeText, nil)
 	}
 }
 
-// fixBad fixes bad collection/language/other/arch/platform tags before i.
-func (b *TagBuilder) fixBad(r *Release, start, i int) {
+// fixBad fixes bad collection/language/other/arch/platform tags before the
+// pivot.
+func (b *TagBuilder) fixBad(r *Release) {
+	start, i := b.start(r, 0), r.pivot
 	// seek non language/edition/collection/cut/other/source/delim
 	for ; i > start &&
 		r.tags[i-1].Is(
@@ -395,8 +670,9 @@ func (b *TagBuilder) fixBad(r *Release, start, i int) {
 	for ; i > start; i-- {
 		switch {
 		case r.tags[i-1].Is(TagTypeCollection) && r.tags[i-1].Collection() == "IMAX",
-			r.tags[i-1].Is(TagTypeOther) && r.tags[i-1].Other() == "REMiX":
-			// ignore imax
+			r.tags[i-1].Is(TagTypeOther) && r.tags[i-1].Other() == "REMiX",
+			r.tags[i-1].Is(TagTypeOther) && r.tags[i-1].Other() == "UNABRIDGED":
+			// ignore imax, remix, unabridged
 		case r.tags[i-1].Is(
 			TagTypeCollection,
 			TagTypeLanguage,
@@ -426,9 +702,9 @@ func (b *TagBuilder) fixSpecial(r *Release, i int, series bool) {
 }
 
 // fixNoText fixes having no text but has a collection tag.
-func (b *TagBuilder) fixNoText(r *Release, end int) {
+func (b *TagBuilder) fixNoText(r *Release) {
 	// bail if any text tags
-	i, n := 0, min(end+1, len(r.tags))
+	i, n := 0, min(r.pivot+1, len(r.tags))
 	for ; i < n; i++ {
 		if r.tags[i].Is(TagTypeText) {
 			return
@@ -455,7 +731,8 @@ func (b *TagBuilder) fixIsolated(r *Release) {
 		) &&
 			isolated(r.tags[:r.end], i-1, -1) &&
 			isolated(r.tags[:r.end], i-1, +1) &&
-			r.tags[i-1].Other() != "REMiX" {
+			r.tags[i-1].Other() != "REMiX" &&
+			r.tags[i-1].Other() != "UNABRIDGED" {
 			r.tags[i-1] = r.tags[i-1].As(TagTypeText, nil)
 		}
 	}
@@ -503,6 +780,72 @@ func (b *TagBuilder) fixMusic(r *Release) {
 	if hasCbr && countCbr == 1 {
 		r.tags[posCbr] = r.tags[posCbr].As(TagTypeContainer, b.containerf)
 	}
+	// music formats (-Single-, (Mixtape), [EP]) and catalog numbers (-CAT123-)
+	dashes := 0
+	for i := 0; i < len(r.tags); i++ {
+		switch {
+		case r.tags[i].Is(TagTypeDelim):
+			if strings.Contains(r.tags[i].Delim(), "-") {
+				dashes++
+			}
+		case r.tags[i].Is(TagTypeMeta):
+			if k, v := r.tags[i].Meta(); k == "site" {
+				if tag, ok := b.musicFormat(r.tags[i].v[0], v); ok {
+					r.tags[i] = tag
+				}
+			}
+		case i < r.end && r.tags[i].Is(TagTypeText) && wrapped(r.tags, i):
+			// only bracketed formats ([Single]) or formats following the
+			// artist and title (Artist-Title-Single-WEB) are not title text
+			s := r.tags[i].Text()
+			if !strings.HasSuffix(r.tags[i-1].Delim(), "-") || dashes > 1 {
+				if tag, ok := b.musicFormat(r.tags[i].v[0], s); ok {
+					r.tags[i] = tag
+					continue
+				}
+			}
+			if dashes > 1 && b.catalog.MatchString(s) && strings.HasSuffix(r.tags[i-1].Delim(), "-") &&
+				strings.HasPrefix(r.tags[i+1].Delim(), "-") && !peek(r.tags, i+2, TagTypeText) {
+				r.tags[i] = NewTag(TagTypeID, nil, []byte(r.tags[i].v[0]), []byte(strings.ReplaceAll(s, "_", " ")))
+			}
+		}
+	}
+}
+
+// musicFormat returns a format meta tag for the music format s (Single,
+// Mixtape), as determined by the source or other tag info kind. Only used for
+// delimited text, so the case of s is ignored.
+func (b *TagBuilder) musicFormat(v, s string) (Tag, bool) {
+	s = strings.ToUpper(s)
+	for _, f := range []taginfo.FindFunc{b.sourcef, b.otherf} {
+		if info := f(s); info != nil && musicFormats[info.Kind()] != "" {
+			return NewTag(TagTypeMeta, nil, []byte(v), []byte("format"), []byte(info.Kind())), true
+		}
+	}
+	return Tag{}, false
+}
+
+// musicFormats are the music formats for tag info kinds.
+var musicFormats = map[string]string{
+	"album":   "Album",
+	"ep":      "EP",
+	"single":  "Single",
+	"maxi":    "Maxi",
+	"mixtape": "Mixtape",
+	"promo":   "Promo",
+	"bootleg": "Bootleg",
+}
+
+// wrapped returns true when the tag at i is wrapped by '-', '()', or '[]'
+// delimiters.
+func wrapped(tags []Tag, i int) bool {
+	if !peek(tags, i-1, TagTypeDelim) || !peek(tags, i+1, TagTypeDelim) {
+		return false
+	}
+	prev, next := tags[i-1].Delim(), tags[i+1].Delim()
+	return (strings.HasSuffix(prev, "-") && strings.HasPrefix(next, "-")) ||
+		(strings.HasSuffix(prev, "(") && strings.HasPrefix(next, ")")) ||
+		(strings.HasSuffix(prev, "[") && strings.HasPrefix(next, "]"))
 }
 
 // collect collects tags into the release.
@@ -514,6 +857,9 @@ func (b *TagBuilder) collect(r *Release) {
 			if r.Platform == "" {
 				r.Platform = r.tags[i].Platform()
 			}
+			if v := r.tags[i].PlatformVersion(); v != "" && r.Software.MinOS == "" {
+				r.Software.MinOS = r.tags[i].Platform() + " " + v
+			}
 		case TagTypeArch:
 			if r.Arch == "" {
 				r.Arch = r.tags[i].Arch()
@@ -528,8 +874,23 @@ func (b *TagBuilder) collect(r *Release) {
 				r.Resolution = r.tags[i].Resolution()
 			}
 		case TagTypeCollection:
+			s := r.tags[i].Collection()
 			if r.Collection == "" {
-				r.Collection = r.tags[i].Collection()
+				r.Collection = s
+			}
+			switch r.tags[i].InfoKind() {
+			case "service":
+				if !contains(r.Service, s) {
+					r.Service = append(r.Service, s)
+				}
+			case "network":
+				if !contains(r.Network, s) {
+					r.Network = append(r.Network, s)
+				}
+			case "publisher":
+				if !contains(r.Publisher, s) {
+					r.Publisher = append(r.Publisher, s)
+				}
 			}
 		case TagTypeDate:
 			r.Year, r.Month, r.Day = r.tags[i].Date()
@@ -542,24 +903,61 @@ func (b *TagBuilder) collect(r *Release) {
 				r.Episode = episode
 			}
 		case TagTypeVersion:
-			if r.Version == "" {
+			if r.tags[i].Build() == 0 && r.Version == "" {
 				r.Version = r.tags[i].Version()
 			}
 		case TagTypeDisc:
 			if r.Disc == "" {
-				r.Disc = fmt.Sprintf("%s", r.tags[i])
+				r.Disc, r.DiscTotal = fmt.Sprintf("%s", r.tags[i]), r.tags[i].DiscTotal()
+			}
+		case TagTypePart:
+			if r.Part == 0 {
+				r.Part, r.PartTotal = r.tags[i].Part()
 			}
 		case TagTypeCodec:
 			r.Codec = append(r.Codec, r.tags[i].Codec())
 		case TagTypeHDR:
 			r.HDR = append(r.HDR, r.tags[i].HDR())
+		case TagTypeBitDepth:
+			if r.BitDepth == 0 {
+				r.BitDepth = r.tags[i].BitDepth()
+			}
+		case TagTypeChroma:
+			if r.Chroma == "" {
+				r.Chroma = r.tags[i].Chroma()
+			}
+		case TagTypeFrameRate:
+			if r.FrameRate == 0 {
+				r.FrameRate = r.tags[i].FrameRate()
+			}
+			r.HFR = r.HFR || r.tags[i].HFR()
+		case TagTypeISBN:
+			if r.ISBN == "" {
+				r.ISBN = r.tags[i].ISBN()
+			}
+		case TagTypeLeague:
+			if r.Sports.League == "" {
+				r.Sports.League, r.Sports.Sport = r.tags[i].League(), r.tags[i].InfoKind()
+			}
+		case TagTypeRound:
+			if r.Sports.Round == 0 {
+				r.Sports.RoundType, r.Sports.Round = r.tags[i].Round()
+			}
 		case TagTypeAudio:
-			r.Audio = append(r.Audio, r.tags[i].Audio())
+			if !musicMarker(r.tags[i]) {
+				r.Audio = append(r.Audio, r.tags[i].Audio())
+			}
 		case TagTypeChannels:
 			if r.Channels == "" {
 				r.Channels = r.tags[i].Channels()
 			}
 		case TagTypeOther:
+			switch s := r.tags[i].Other(); s {
+			case "RETAiL":
+				r.Retail = true
+			case "UNABRIDGED":
+				r.Unabridged = true
+			}
 			r.Other = append(r.Other, r.tags[i].Other())
 		case TagTypeCut:
 			r.Cut = append(r.Cut, r.tags[i].Cut())
@@ -579,6 +977,9 @@ func (b *TagBuilder) collect(r *Release) {
 			if r.Container == "" {
 				r.Container = r.tags[i].Container()
 			}
+			if kind := r.tags[i].InfoKind(); r.BookFormat == "" && (kind == "ebook" || kind == "comic") {
+				r.BookFormat = strings.ToUpper(r.tags[i].Container())
+			}
 		case TagTypeGenre:
 			if r.Genre == "" {
 				r.Genre = r.tags[i].Genre()
@@ -599,13 +1000,40 @@ func (b *TagBuilder) collect(r *Release) {
 				r.Pass = v
 			case k == "req":
 				r.Req = true
+			case k == "label" && r.Label == "":
+				r.Label = v
+			case k == "format", k == "label":
+			case k == "imdb" && r.IMDbID == "" && ValidIMDbID(v):
+				r.IMDbID = v
+			case k == "tmdb" && r.TMDbID == 0 && validDBID(v):
+				r.TMDbID, _ = strconv.Atoi(v)
+			case k == "tvdb" && r.TVDbID == 0 && validDBID(v):
+				r.TVDbID, _ = strconv.Atoi(v)
+			case k == "imdb", k == "tmdb", k == "tvdb":
+				// drop invalid and repeated ids
 			default:
 				r.Meta = append(r.Meta, k+":"+v)
 			}
 		case TagTypeExt:
 			r.Ext = r.tags[i].Ext()
+		default:
+			if r.tags[i].typ.Custom() {
+				if r.Extra == nil {
+					r.Extra = make(map[string][]string)
+				}
+				k := strings.ToLower(r.tags[i].typ.Name())
+				r.Extra[k] = append(r.Extra[k], r.tags[i].Extra())
+			}
 		}
 	}
+	// audio tracks
+	r.AudioTracks = AudioTracks(r.tags)
+	// proper/repack revision
+	b.revision(r)
+	// hdr implies 10-bit
+	if r.BitDepth == 0 && len(r.HDR) != 0 && !contains(r.HDR, "SDR") {
+		r.BitDepth = 10
+	}
 	// collect year, month, day from unset date tags
 	for i := len(r.dates); i > 0; i-- {
 		year, month, day := r.tags[r.dates[i-1]].Date()
@@ -621,16 +1049,344 @@ func (b *TagBuilder) collect(r *Release) {
 	}
 }
 
+// revisions are the revision counts of revision markers.
+var revisions = map[string]int{
+	"PROPER":      1,
+	"REPACK":      1,
+	"RERiP":       1,
+	"REREPACK":    2,
+	"REAL.PROPER": 2,
+}
+
+// revision collects the revision markers on the release, setting the revision
+// to the highest marker (PROPER, REPACK, REPACK2, PROPER3) plus one for each
+// REAL marker.
+func (b *TagBuilder) revision(r *Release) {
+	var n, real int
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeOther) || r.tags[i].InfoKind() != "revision" {
+			continue
+		}
+		s := r.tags[i].Other()
+		r.RevisionMarkers = append(r.RevisionMarkers, s)
+		if s == "REAL" {
+			real++
+			continue
+		}
+		c := revisions[s]
+		if t := r.tags[i].Text(); '2' <= t[len(t)-1] && t[len(t)-1] <= '9' {
+			c = int(t[len(t)-1] - '0')
+		}
+		n = max(n, c)
+	}
+	r.Revision = n + real
+}
+
+// languages collects the audio and subtitle languages on the release, using
+// the language tag info kind as the role. Plain languages followed by a
+// subtitle tag (ENG.SUBS) are subtitle languages, otherwise audio languages
+// (German.DL) on releases other than books. Markers (DL, MULTi) are added to
+// other.
+func (b *TagBuilder) languages(r *Release) {
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeLanguage) {
+			continue
+		}
+		s, role := r.tags[i].Language(), languageRole(r.tags[i])
+		switch role {
+		case "language":
+			j := i + 1
+			for ; j < len(r.tags) && r.tags[j].Is(TagTypeWhitespace, TagTypeDelim); j++ {
+			}
+			if j < len(r.tags) && r.tags[j].Is(TagTypeLanguage) && isSubtitleMarker(r.tags[j]) {
+				if !contains(r.SubtitleLanguages, s) {
+					r.SubtitleLanguages = append(r.SubtitleLanguages, s)
+				}
+				r.HardcodedSubs = r.HardcodedSubs || languageRole(r.tags[j]) == "hardsub"
+				i = j
+				continue
+			}
+			fallthrough
+		case "audio":
+			// books have no audio
+			if r.Type != Book && r.Type != Comic && r.Type != Magazine && !contains(r.AudioLanguages, s) {
+				r.AudioLanguages = append(r.AudioLanguages, s)
+			}
+		case "marker":
+			if n := languageCount(r.tags[i]); n != 0 {
+				r.LanguageCount = n
+			}
+		case "subtitle":
+			if !contains(r.SubtitleLanguages, s) {
+				r.SubtitleLanguages = append(r.SubtitleLanguages, s)
+			}
+		case "hardsub":
+			// hard subs with a language of their own (KORSUB)
+			if info := r.tags[i].Info(); info != nil && info.Code() != "" && !contains(r.SubtitleLanguages, s) {
+				r.SubtitleLanguages = append(r.SubtitleLanguages, s)
+			}
+			r.HardcodedSubs = true
+		}
+	}
+}
+
+// languageRole returns the role of a language tag (language, audio, marker,
+// subtitle, hardsub). Dubs matched by the
+// subtitle info ([A-Z]*DUB) are audio.
+func languageRole(tag Tag) string {
+	role := tag.InfoKind()
+	if s := tag.Language(); role == "subtitle" && strings.Contains(s, "DUB") {
+		return "audio"
+	}
+	return role
+}
+
+// languageCount returns the language count of a multi language tag (MULTi5).
+func languageCount(tag Tag) int {
+	if !strings.HasPrefix(tag.Language(), "MULTi") {
+		return 0
+	}
+	v := strings.TrimRightFunc(tag.v[1], unicode.IsDigit)
+	n, _ := strconv.Atoi(tag.v[1][len(v):])
+	return n
+}
+
+// isSubtitleMarker returns true when tag is a subtitle or hardsub language tag
+// without a language of its own (SUBS, SUBBED, HC).
+func isSubtitleMarker(tag Tag) bool {
+	switch s := tag.Language(); languageRole(tag) {
+	case "subtitle":
+		return strings.HasPrefix(s, "SUB")
+	case "hardsub":
+		return true
+	}
+	return false
+}
+
+// musicMarker returns true when tag is a bare music quality marker lexed by
+// NewMusicQualityLexer (24-96, MP3 320, MP3 V0).
+func musicMarker(tag Tag) bool {
+	return tag.Is(TagTypeAudio) && (strings.Trim(tag.v[0], "0123456789.,") == "" || musicPresets[strings.ToUpper(tag.v[0])] != 0)
+}
+
+// music collects the music quality, catalog number, and format on music
+// releases.
+func (b *TagBuilder) music(r *Release) {
+	if r.Type != Music {
+		// lexed bit depth, sample rate, and bitrate numbers (24-96, MP3 320)
+		// are only kept on music releases
+		for i := 0; i < len(r.tags); i++ {
+			if musicMarker(r.tags[i]) {
+				r.tags[i] = NewTag(TagTypeText, nil, []byte(r.tags[i].v[0]), []byte(r.tags[i].v[0]))
+			}
+		}
+		return
+	}
+	var q MusicQuality
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeAudio) {
+			continue
+		}
+		switch s := r.tags[i].Audio(); {
+		case s == "CBR", s == "VBR":
+			q.Mode = s
+		case s == "LOSSLESS":
+			q.Mode = "Lossless"
+		case s == "FLAC", s == "ALAC":
+			q.Mode = "Lossless"
+			if q.Format == "" {
+				q.Format = s
+			}
+		case strings.HasSuffix(r.tags[i].InfoKind(), "codec"):
+			if q.Format == "" {
+				q.Format = s
+			}
+		case musicPresets[s] != 0:
+			q.Preset = s
+			if q.Mode == "" {
+				q.Mode = "VBR"
+			}
+		case strings.HasSuffix(s, "Kbps"):
+			q.Bitrate, _ = strconv.Atoi(strings.TrimSuffix(s, "Kbps"))
+		case strings.HasSuffix(s, "khz"):
+			q.SampleRate = musicSampleRate(strings.TrimSuffix(s, "khz"))
+		case strings.HasSuffix(s, "BIT"):
+			q.BitDepth, _ = strconv.Atoi(strings.TrimSuffix(s, "BIT"))
+		}
+	}
+	switch {
+	case q.Bitrate == 0 && q.Preset != "":
+		q.Bitrate = musicPresets[q.Preset]
+	case q.Mode == "" && q.Bitrate != 0:
+		q.Mode = "CBR"
+	}
+	r.MusicQuality = q
+	// catalog and label, from music ids ((CAT-001)) and sites ([ABC123])
+	if b.catalog.MatchString(r.ID) {
+		r.Catalog = r.ID
+	}
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeMeta) {
+			continue
+		}
+		k, v := r.tags[i].Meta()
+		switch info := b.labelf(v); {
+		case k != "site":
+			continue
+		case r.Catalog == "" && b.catalog.MatchString(v):
+			r.Catalog, r.tags[i] = v, NewTag(TagTypeMeta, nil, []byte(r.tags[i].v[0]), []byte("catalog"), []byte(v))
+		case r.Label == "" && info != nil:
+			r.Label, r.tags[i] = info.Tag(), NewTag(TagTypeMeta, nil, []byte(r.tags[i].v[0]), []byte("label"), []byte(info.Tag()))
+		default:
+			continue
+		}
+		if r.Site == v {
+			r.Site = ""
+		}
+	}
+	// format, preferring release formats (Single) over promo, bootleg, mixtape
+	var secondary bool
+	for i := 0; i < len(r.tags); i++ {
+		var kind string
+		switch {
+		case r.tags[i].Is(TagTypeSource, TagTypeOther):
+			kind = r.tags[i].InfoKind()
+		case r.tags[i].Is(TagTypeMeta):
+			if k, v := r.tags[i].Meta(); k == "format" {
+				kind = v
+			}
+		}
+		switch {
+		case musicFormats[kind] == "":
+		case r.MusicFormat == "", secondary && !musicSecondary(kind):
+			r.MusicFormat, secondary = musicFormats[kind], musicSecondary(kind)
+		}
+	}
+}
+
+// game sets the game release details on game and app releases. Unknown
+// releases with a build id (Game.Build.12345678), and app releases with a
+// date version and a language count (Game.v20230101.MULTi12) are games. Build
+// ids are only kept on game and app releases, and bundled content is only
+// collected on game releases.
+func (b *TagBuilder) game(r *Release) {
+	build := -1
+	for i := 0; i < len(r.tags) && build == -1; i++ {
+		if r.tags[i].Is(TagTypeVersion) && r.tags[i].Build() != 0 {
+			build = i
+		}
+	}
+	switch {
+	case r.Type == Unknown && build != -1,
+		r.Type == App && r.LanguageCount != 0 && b.dated.MatchString(r.Version):
+		r.Type = Game
+	}
+	for i := build; i != -1 && i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeVersion) || r.tags[i].Build() == 0 {
+			continue
+		}
+		switch {
+		case r.Type == Game && r.Game.Build == 0:
+			r.Game.Build = r.tags[i].Build()
+		case r.Type == App && r.Version == "":
+			r.Version = r.tags[i].Normalize()
+		case r.Type != Game && r.Type != App:
+			r.tags[i] = NewTag(TagTypeText, nil, []byte(r.tags[i].v[0]), []byte(r.tags[i].v[0]))
+		}
+	}
+	if r.Type != Game && r.Type != App {
+		return
+	}
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeOther) {
+			continue
+		}
+		kind := r.tags[i].InfoKind()
+		switch {
+		case gameIncluded[kind] == "":
+			continue
+		case b.included.MatchString(r.tags[i].Text()):
+			if r.Type == Game && !contains(r.Game.Included, gameIncluded[kind]) {
+				r.Game.Included = append(r.Game.Included, gameIncluded[kind])
+			}
+			continue
+		}
+		switch kind {
+		case "update":
+			r.Game.Update = true
+		case "dlc":
+			r.Game.DLC = true
+		case "crack", "fix":
+			r.Game.CrackOnly = true
+		}
+	}
+}
+
+// gameIncluded are the bundled content names for game other kinds.
+var gameIncluded = map[string]string{
+	"update":  "Update",
+	"dlc":     "DLC",
+	"crack":   "Crack",
+	"fix":     "Fix",
+	"bonus":   "Bonus",
+	"trainer": "Trainer",
+}
+
+// musicSecondary returns true for the promo, bootleg, and mixtape kinds.
+func musicSecondary(kind string) bool {
+	return kind == "promo" || kind == "bootleg" || kind == "mixtape"
+}
+
+// musicPresets are the nominal bitrates for LAME presets.
+var musicPresets = map[string]int{
+	"V0":  245,
+	"V1":  225,
+	"V2":  190,
+	"APX": 245,
+	"APS": 190,
+}
+
+// musicSampleRate returns the sample rate in Hz for a kHz value.
+func musicSampleRate(s string) int {
+	switch s {
+	case "44", "44.1":
+		return 44100
+	case "88", "88.2":
+		return 88200
+	case "176", "176.4":
+		return 176400
+	case "352", "352.8":
+		return 352800
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+	return int(f * 1000)
+}
+
 // inspect inspects the release, returning its expected type.
 func (b *TagBuilder) inspect(r *Release, initial bool) Type {
 	if r.Type != Unknown {
 		return r.Type
 	}
 	n := len(r.tags)
+	// sports league with an event date or round, superseding movie, series
+	// and episode tags when not a series or episode (S01E02)
+	var league, event bool
+	for i := 0; i < n; i++ {
+		league = league || r.tags[i].Is(TagTypeLeague)
+		event = event || r.tags[i].Is(TagTypeDate, TagTypeRound)
+	}
+	league = league && event && r.Series == 0 && r.Episode == 0
 	// inspect types
 	var app, series, movie bool
 	for i := n; i > 0; i-- {
 		typ := r.tags[i-1].InfoType()
+		switch {
+		case league && typ.Is(Movie, Series, Episode, Sports):
+			return Sports
+		case typ == Sports:
+			// league without an event
+			continue
+		}
 		app, series, movie = app || typ == App, series || r.tags[i-1].Is(TagTypeSeries), movie || typ == Movie
 		switch typ {
 		case Book, Game:
@@ -660,6 +1416,10 @@ func (b *TagBuilder) inspect(r *Release, initial bool) Type {
 			return typ
 		case Audiobook, Comic, Magazine:
 			return typ
+		default:
+			if typ.Custom() {
+				return typ
+			}
 		}
 		// exclusive tag not superseded by version/episode/date
 		if r.tags[i-1].InfoExcl() &&
@@ -669,6 +1429,9 @@ func (b *TagBuilder) inspect(r *Release, initial bool) Type {
 			return typ
 		}
 	}
+	if league {
+		return Sports
+	}
 	// check music style tag delimiters
 	for count, i := 0, n-1; i > 1; i-- {
 		if r.tags[i-1].Is(
@@ -743,9 +1506,140 @@ func (b *TagBuilder) specialDate(r *Release) {
 	}
 }
 
+// issues sets the volume and issue numbers on comics and magazines. The
+// volume and number series tag (Vol.1.No.26) is moved to the volume and
+// issue, otherwise the first issue text after the title (#012, N26, No.9123,
+// 001-006, 012 (of 6), Annual 2019) is retyped as issue tags.
+func (b *TagBuilder) issues(r *Release) {
+	if r.Type != Comic && r.Type != Magazine {
+		return
+	}
+	// volume and number
+	for i := 0; i < len(r.tags); i++ {
+		if r.tags[i].Is(TagTypeSeries) {
+			r.Volume, r.Issue = r.tags[i].Series()
+			if v := r.tags[i].Episodes(); len(v) > 1 {
+				r.IssueEnd = v[len(v)-1]
+			} else if j, delim := skipDelims(r.tags, i); delim == "-" && peek(r.tags, j, TagTypeText) && b.digits.MatchString(r.tags[j].Text()) {
+				r.IssueEnd, _ = strconv.Atoi(r.tags[j].Text())
+				r.tags[j] = r.tags[j].As(TagTypeIssue, nil)
+			}
+			r.Series, r.Episode = 0, 0
+			return
+		}
+	}
+	// issue text, skipping the first text (title)
+	title := false
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeText) {
+			continue
+		} else if !title {
+			title = true
+			continue
+		}
+		s := r.tags[i].Text()
+		j, delim := skipDelims(r.tags, i)
+		number := peek(r.tags, j, TagTypeText) && b.digits.MatchString(r.tags[j].Text())
+		switch {
+		case strings.EqualFold(s, "annual"):
+			r.Annual, r.tags[i] = 1, r.tags[i].As(TagTypeIssue, nil)
+			if peek(r.tags, j, TagTypeText, TagTypeDate) && b.digits.MatchString(r.tags[j].Text()) {
+				r.Annual, _ = strconv.Atoi(r.tags[j].Text())
+				if r.tags[j].Is(TagTypeText) {
+					r.tags[j] = r.tags[j].As(TagTypeIssue, nil)
+				}
+			}
+			return
+		case issueMarkers[strings.ToLower(s)] && number:
+			r.tags[i] = r.tags[i].As(TagTypeIssue, nil)
+			i, s = j, r.tags[j].Text()
+		case b.issue.MatchString(s):
+		case b.digits.MatchString(s) && (len(s) <= 3 || s[0] == '0') &&
+			(!peek(r.tags, j, TagTypeText) || strings.ContainsAny(delim, "([") || delim == "-" && number):
+		default:
+			continue
+		}
+		r.Issue, _ = strconv.Atoi(b.digsuf.FindString(s))
+		r.tags[i] = r.tags[i].As(TagTypeIssue, nil)
+		// range (001-006)
+		if j, delim := skipDelims(r.tags, i); delim == "-" && peek(r.tags, j, TagTypeText) && b.digits.MatchString(r.tags[j].Text()) {
+			r.IssueEnd, _ = strconv.Atoi(r.tags[j].Text())
+			r.tags[j], i = r.tags[j].As(TagTypeIssue, nil), j
+		}
+		// total (012 (of 6))
+		if j, _ := skipDelims(r.tags, i); peek(r.tags, j, TagTypeText) && strings.EqualFold(r.tags[j].Text(), "of") {
+			if k, _ := skipDelims(r.tags, j); peek(r.tags, k, TagTypeText) && b.digits.MatchString(r.tags[k].Text()) {
+				r.IssueTotal, _ = strconv.Atoi(r.tags[k].Text())
+				r.tags[j], r.tags[k] = r.tags[j].As(TagTypeIssue, nil), r.tags[k].As(TagTypeIssue, nil)
+			}
+		}
+		return
+	}
+}
+
+// issueMarkers are the issue number prefixes.
+var issueMarkers = map[string]bool{
+	"#":     true,
+	"no":    true,
+	"nr":    true,
+	"issue": true,
+}
+
+// threeDLayouts are the 3D layouts of other tags.
+var threeDLayouts = map[string]ThreeD{
+	"SBS":      {Stereoscopic: true, Layout: "SBS"},
+	"Half-SBS": {Stereoscopic: true, Layout: "SBS", Half: true},
+	"OU":       {Stereoscopic: true, Layout: "OU"},
+	"Half-OU":  {Stereoscopic: true, Layout: "OU", Half: true},
+	"MVC":      {Stereoscopic: true, Layout: "MVC"},
+	"Anaglyph": {Stereoscopic: true, Layout: "Anaglyph"},
+}
+
+// threeD sets the 3D information from the remaining 3D marker and layout
+// tags.
+func (b *TagBuilder) threeD(r *Release) {
+	for i := 0; i < len(r.tags); i++ {
+		if !r.tags[i].Is(TagTypeOther, TagTypeSource) {
+			continue
+		}
+		switch d, ok := threeDLayouts[r.tags[i].Normalize()]; {
+		case ok && r.tags[i].Is(TagTypeOther) && r.ThreeD.Layout == "":
+			r.ThreeD = d
+		case r.tags[i].InfoKind() == "3d":
+			r.ThreeD.Stereoscopic = true
+		}
+	}
+}
+
+// origin sets the release origin from a group's tag info kind (scene, p2p,
+// internal), or to internal when tagged INTERNAL. Naming style is not a
+// reliable signal, so the origin is otherwise left unknown.
+func (b *TagBuilder) origin(r *Release) {
+	for i := 0; i < len(r.tags); i++ {
+		if r.tags[i].Is(TagTypeGroup) {
+			if origin := ParseOrigin(r.tags[i].InfoKind()); origin != OriginUnknown {
+				r.Origin = origin
+				return
+			}
+		}
+	}
+	if contains(r.Other, "INTERNAL") {
+		r.Origin = OriginInternal
+	}
+}
+
 // unset unsets exclusive and other misrecognized tags on the release.
 func (b *TagBuilder) unset(r *Release) {
-	movieSeriesEpisodeMusicGame, grabSource := r.Type.Is(Movie, Series, Episode, Music, Game), false
+	movieSeriesEpisodeMusicGameSports, grabSource := r.Type.Is(Movie, Series, Episode, Music, Game, Sports), false
+	// a movie part directly following the title and prior to the date is part
+	// of the title (Deathly.Hallows.Part.1.2010), but not when bracketed
+	// (Title.(Part.2).2010)
+	date := -1
+	for i := len(r.tags) - 1; r.Type == Movie && i >= 0; i-- {
+		if r.tags[i].Is(TagTypeDate) {
+			date = i
+		}
+	}
 	for i := 0; i < len(r.tags); i++ {
 		// if source had been previously reset, save it
 		if grabSource && r.tags[i].Is(TagTypeSource) && r.Source == "" {
@@ -773,6 +1667,7 @@ func (b *TagBuilder) unset(r *Release) {
 			TagTypeGenre,
 			TagTypeGroup,
 			TagTypeExt,
+			TagTypeLeague,
 		) && r.tags[i].InfoExcl() {
 			switch typ, s := r.tags[i].TagType(), r.tags[i].Normalize(); {
 			case typ == TagTypePlatform && r.Platform == s && !contains(r.Other, "Strategy.Guide"):
@@ -813,15 +1708,21 @@ func (b *TagBuilder) unset(r *Release) {
 				r.Group, r.tags[i] = "", r.tags[i].As(TagTypeText, nil)
 			case typ == TagTypeExt && r.Ext == s:
 				r.Ext, r.tags[i] = "", r.tags[i].As(TagTypeText, nil)
+			case typ == TagTypeLeague:
+				r.Sports, r.tags[i] = SportsEvent{}, r.tags[i].As(TagTypeText, nil)
 			}
-		} else if !movieSeriesEpisodeMusicGame && r.tags[i].Is(TagTypeSource) && ityp.Is(Movie, Series, Episode) {
+		} else if !movieSeriesEpisodeMusicGameSports && r.tags[i].Is(TagTypeSource) && ityp.Is(Movie, Series, Episode) {
 			// reset movie/series/episode source tags
 			if r.Source == r.tags[i].Normalize() {
 				r.Source = ""
 			}
 			r.tags[i], grabSource = r.tags[i].As(TagTypeText, nil), true
-		} else if !movieSeriesEpisodeMusicGame && r.tags[i].Is(TagTypeChannels) {
+		} else if !movieSeriesEpisodeMusicGameSports && r.tags[i].Is(TagTypeChannels) {
 			r.tags[i], r.Channels = r.tags[i].As(TagTypeText, nil), ""
+		} else if r.Type != Sports && r.tags[i].Is(TagTypeRound) {
+			r.tags[i], r.Sports = r.tags[i].As(TagTypeText, nil), SportsEvent{}
+		} else if i < date && r.tags[i].Is(TagTypePart) && peek(r.tags, i-2, TagTypeText) && peek(r.tags, i-1, TagTypeDelim) && !strings.ContainsAny(r.tags[i-1].Delim(), "()[]{}") {
+			r.tags[i], r.Part, r.PartTotal = r.tags[i].As(TagTypeText, nil), 0, 0
 		}
 	}
 }
@@ -841,6 +1742,8 @@ func (b *TagBuilder) titles(r *Release) int {
 		f = b.bookTitles
 	case App, Game:
 		f = b.appTitle
+	case Sports:
+		f = b.sportsTitles
 	default:
 		f = b.defaultTitle
 	}
@@ -895,7 +1798,7 @@ func (b *TagBuilder) movieTitles(r *Release) int {
 	}
 	// alternate subtitle delimiter
 	if i := strings.LastIndexByte(r.Title, '~'); i != -1 && r.Subtitle == "" {
-		r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], isTitleTrimDelim), strings.TrimLeftFunc(r.Title[i+1:], isTitleTrimDelim)
+		r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Title[i+1:], b.delims.IsTitleTrim)
 	}
 	return min(start+offset, resolution)
 }
@@ -913,8 +1816,8 @@ func (b *TagBuilder) boxTitle(r *Release, start, offset int) int {
 			// consume remaining text, cut, edition
 			suffix, offset := b.title(r.tags[n:], TagTypeText, TagTypeCut, TagTypeEdition)
 			// reform title, subtitle
-			r.Title = strings.TrimRightFunc(strings.TrimSuffix(r.Title, prefix), isTitleTrimDelim)
-			r.Subtitle = prefix + " " + strings.TrimRightFunc(suffix, isBreakDelim)
+			r.Title = strings.TrimRightFunc(strings.TrimSuffix(r.Title, prefix), b.delims.IsTitleTrim)
+			r.Subtitle = prefix + " " + strings.TrimRightFunc(suffix, b.delims.IsBreak)
 			return n + offset
 		}
 	}
@@ -966,6 +1869,80 @@ func (b *TagBuilder) episodeTitles(r *Release) int {
 	return pos + offset
 }
 
+// sportsTitles sets the titles for sports, keeping the league as part of the
+// title, and splitting competing teams (X vs Y) from the subtitle.
+func (b *TagBuilder) sportsTitles(r *Release) int {
+	// league tags are title text
+	var leagues []int
+	var season string
+	for i := 0; i < len(r.tags); i++ {
+		switch {
+		case r.tags[i].Is(TagTypeLeague):
+			r.tags[i], leagues = r.tags[i].As(TagTypeText, nil), append(leagues, i)
+		case r.tags[i].Is(TagTypeText) && peek(r.tags, i+1, TagTypeDelim) && r.tags[i+1].Delim() == "-" && peek(r.tags, i+2, TagTypeDate):
+			// season start year (2011-2012)
+			if y, _ := strconv.Atoi(r.tags[i].Text()); 1900 < y && y < 2100 {
+				season = r.tags[i].Text()
+			}
+		}
+	}
+	pos := b.movieTitles(r)
+	for _, i := range leagues {
+		r.tags[i] = r.tags[i].Revert()
+	}
+	if season != "" {
+		r.Title = strings.TrimSuffix(r.Title, " "+season)
+	}
+	// seek text after date/round, collecting any skipped text
+	for ; pos < len(r.tags) && !r.tags[pos].Is(TagTypeDate, TagTypeRound); pos++ {
+		if r.tags[pos].Is(TagTypeText) {
+			r.unused = append(r.unused, pos)
+		}
+	}
+	for ; pos < len(r.tags) && r.tags[pos].Is(
+		TagTypeDelim,
+		TagTypeDate,
+		TagTypeRound,
+		TagTypeSource,
+		TagTypeCollection,
+		TagTypeOther,
+		TagTypeLanguage,
+	); pos++ {
+		// event numbers are part of the title (UFC.179)
+		if !r.tags[pos].Is(TagTypeRound) || r.Title == "" {
+			continue
+		}
+		if kind, n := r.tags[pos].Round(); kind == "Event" {
+			r.Title += " " + strconv.Itoa(n)
+		}
+	}
+	if pos == len(r.tags) || !r.tags[pos].Is(TagTypeText) {
+		return pos
+	}
+	var offset int
+	r.Subtitle, offset = b.title(r.tags[pos:], TagTypeText)
+	pos += offset
+	if v := b.versus.Split(r.Subtitle, 2); len(v) == 2 {
+		away := strings.Fields(v[1])
+		for len(away) > 1 && sportsSuffixes[strings.ToLower(away[len(away)-1])] {
+			away = away[:len(away)-1]
+		}
+		r.Sports.Teams = []string{strings.TrimSpace(v[0]), strings.Join(away, " ")}
+	}
+	return pos
+}
+
+// sportsSuffixes are the trailing event words trimmed from the away team.
+var sportsSuffixes = map[string]bool{
+	"highlights": true,
+	"replay":     true,
+	"extended":   true,
+	"condensed":  true,
+	"full":       true,
+	"game":       true,
+	"match":      true,
+}
+
 // musicTitles sets the titles for music.
 func (b *TagBuilder) musicTitles(r *Release) int {
 	var i int
@@ -973,7 +1950,7 @@ func (b *TagBuilder) musicTitles(r *Release) int {
 	// split artist, title
 	for _, s := range []string{" - ", "--", "~", "-"} {
 		if j := strings.LastIndex(r.Title, s); j != -1 {
-			r.Artist, r.Title = strings.TrimRightFunc(r.Title[:j], isTitleTrimDelim), strings.TrimLeftFunc(r.Title[j+len(s):], isBreakDelim)
+			r.Artist, r.Title = strings.TrimRightFunc(r.Title[:j], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Title[j+len(s):], b.delims.IsBreak)
 			break
 		}
 	}
@@ -1006,11 +1983,15 @@ func (b *TagBuilder) musicTitles(r *Release) int {
 			r.Subtitle, i = b.mixTitle(r, i+1)
 		}
 	}
+	// label packs
+	if contains(r.Other, "LABEL.PACK") {
+		r.Label = r.Title
+	}
 	if r.Subtitle == "" && r.Artist != "" {
 		// try to split artist
 		for _, s := range []string{" - ", "--", "~"} {
 			if j := strings.LastIndex(r.Artist, s); j != -1 {
-				r.Artist, r.Title, r.Subtitle = strings.TrimRightFunc(r.Artist[:j], isTitleTrimDelim), strings.TrimLeftFunc(r.Artist[j+len(s):], isBreakDelim), r.Title
+				r.Artist, r.Title, r.Subtitle = strings.TrimRightFunc(r.Artist[:j], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Artist[j+len(s):], b.delims.IsBreak), r.Title
 				break
 			}
 		}
@@ -1073,13 +2054,19 @@ func (b *TagBuilder) bookTitles(r *Release) int {
 		}
 		r.Title += s
 	}
+	// audiobook narrator (Read by ..., Narrated by ...)
+	if m := b.narrator.FindStringSubmatchIndex(r.Title); r.Type == Audiobook && m != nil {
+		r.Narrator, r.Title = r.Title[m[2]:m[3]], strings.TrimRightFunc(r.Title[:m[0]], func(c rune) bool {
+			return c == ';' || c == '-' || b.delims.IsTitleTrim(c)
+		})
+	}
 	if i := strings.LastIndexByte(r.Title, ';'); i != -1 {
-		r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], isTitleTrimDelim), strings.TrimLeftFunc(r.Title[i+1:], isTitleTrimDelim)
+		r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Title[i+1:], b.delims.IsTitleTrim)
 	}
 	if r.Artist == "" {
 		for _, s := range []string{" - ", "--", "~"} {
 			if i := strings.Index(r.Title, s); i != -1 {
-				r.Artist, r.Title = strings.TrimRightFunc(r.Title[:i], isTitleTrimDelim), strings.TrimLeftFunc(r.Title[i+len(s):], isBreakDelim)
+				r.Artist, r.Title = strings.TrimRightFunc(r.Title[:i], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Title[i+len(s):], b.delims.IsBreak)
 				break
 			}
 		}
@@ -1087,22 +2074,42 @@ func (b *TagBuilder) bookTitles(r *Release) int {
 	if r.Subtitle == "" {
 		for _, s := range []string{" - ", "--", "~"} {
 			if i := strings.Index(r.Title, s); i != -1 {
-				r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], isBreakDelim), strings.TrimLeftFunc(r.Title[i+len(s):], isTitleTrimDelim)
+				r.Title, r.Subtitle = strings.TrimRightFunc(r.Title[:i], b.delims.IsBreak), strings.TrimLeftFunc(r.Title[i+len(s):], b.delims.IsTitleTrim)
 				break
 			}
 		}
 	}
 	if i := strings.LastIndexByte(r.Title, '-'); r.Artist == "" && i != -1 {
-		artist, title := strings.TrimRightFunc(r.Title[:i], isTitleTrimDelim), strings.TrimLeftFunc(r.Title[i+1:], isBreakDelim)
+		artist, title := strings.TrimRightFunc(r.Title[:i], b.delims.IsTitleTrim), strings.TrimLeftFunc(r.Title[i+1:], b.delims.IsBreak)
 		if !b.digsuf.MatchString(artist) && !b.digpre.MatchString(title) {
 			r.Artist, r.Title = artist, title
 		}
 	}
+	// publisher in place of the author (McGraw.Hill.-.Title)
+	if info := b.publisherf(r.Artist); r.Artist != "" && info != nil && !contains(r.Publisher, info.Tag()) {
+		r.Publisher = append(r.Publisher, info.Tag())
+	}
 	return pos
 }
 
 // appTitle sets the an app title.
 func (b *TagBuilder) appTitle(r *Release) int {
+	// editions, portable, bundled tools
+	for i := 0; i < len(r.tags); i++ {
+		switch {
+		case r.tags[i].Is(TagTypeText):
+			if s := appEditions[strings.ToLower(r.tags[i].Text())]; s != "" && !contains(r.Software.Editions, s) {
+				r.Software.Editions = append(r.Software.Editions, s)
+			}
+		case !r.tags[i].Is(TagTypeOther):
+		case r.tags[i].InfoKind() == "portable":
+			r.Software.Portable = true
+		case appTools[r.tags[i].InfoKind()] && b.included.MatchString(r.tags[i].Text()):
+			if s := r.tags[i].InfoTitle(); !contains(r.Software.Tools, s) {
+				r.Software.Tools = append(r.Software.Tools, s)
+			}
+		}
+	}
 	// seek to text
 	var pos int
 	for pos = 0; pos < len(r.tags) && !r.tags[pos].Is(TagTypeText, TagTypeDate); pos++ {
@@ -1112,6 +2119,32 @@ func (b *TagBuilder) appTitle(r *Release) int {
 	return pos + offset
 }
 
+// appEditions are the software editions.
+var appEditions = map[string]string{
+	"pro":          "Pro",
+	"professional": "Professional",
+	"enterprise":   "Enterprise",
+	"ultimate":     "Ultimate",
+	"premium":      "Premium",
+	"business":     "Business",
+	"standard":     "Standard",
+	"home":         "Home",
+	"education":    "Education",
+	"community":    "Community",
+	"developer":    "Developer",
+	"lite":         "Lite",
+	"ltsc":         "LTSC",
+	"ltsb":         "LTSB",
+}
+
+// appTools are the bundled tool kinds.
+var appTools = map[string]bool{
+	"crack":  true,
+	"keygen": true,
+	"serial": true,
+	"patch":  true,
+}
+
 // defaultTitle sets the default title.
 func (b *TagBuilder) defaultTitle(r *Release) int {
 	// seek to text
@@ -1163,28 +2196,67 @@ loop:
 		s = b.plus.ReplaceAllString(s, " ")
 	}
 	// trim
-	return strings.TrimFunc(s, isTitleTrimDelim), i
+	return strings.TrimFunc(s, b.delims.IsTitleTrim), i
 }
 
 // unused sets the unused text on the release.
 func (b *TagBuilder) unused(r *Release, i int) {
 	// collect
+	part := -1
 	for ; i < len(r.tags); i++ {
-		if r.tags[i].Is(TagTypeText) {
+		switch {
+		case r.tags[i].Is(TagTypeText):
 			r.unused = append(r.unused, i)
+		case r.tags[i].Is(TagTypePart) && len(r.unused) != 0 && r.Group == "" && trailing(r.tags, i+1):
+			// trailing part following unused text is unused text, and not a
+			// group (Working.For.Wii.Part.1)
+			v := r.tags[i].v[0]
+			r.tags[i] = NewTag(TagTypeText, nil, []byte(v), []byte(strings.Join(strings.FieldsFunc(v, b.delims.IsAny), " ")))
+			r.Part, r.PartTotal, r.unused, part = 0, 0, append(r.unused, i), i
 		}
 	}
 	// final conversions
-	if n := len(r.unused); n != 0 {
+	if n := len(r.unused); n != 0 && r.unused[n-1] != part {
 		switch s := r.tags[r.unused[n-1]].Text(); {
 		case r.Sum == "" && b.sum.MatchString(s) && strings.ContainsAny(s, "0123456789"):
 			r.Sum, r.unused = s, r.unused[:n-1]
-		case r.Group == "" && !b.digits.MatchString(s):
+		case r.Group == "" && !b.digits.MatchString(s) && !b.genre(r, r.unused[n-1]):
 			r.Group, r.unused = s, r.unused[:n-1]
 		}
 	}
 }
 
+// trailing returns true when there are only whitespace, delimiter, ext, or
+// meta tags from i.
+func trailing(tags []Tag, i int) bool {
+	for ; i < len(tags); i++ {
+		if !tags[i].Is(TagTypeWhitespace, TagTypeDelim, TagTypeExt, TagTypeMeta) {
+			return false
+		}
+	}
+	return true
+}
+
+// genre returns true when the text tag at i closes a parenthesized year and
+// genre (Album (2000 - Alternative Rock)).
+func (b *TagBuilder) genre(r *Release, i int) bool {
+	if !peek(r.tags, i+1, TagTypeDelim) || !strings.HasPrefix(r.tags[i+1].Delim(), ")") {
+		return false
+	}
+	date := false
+	for ; i > 0; i-- {
+		switch {
+		case r.tags[i-1].Is(TagTypeDate):
+			date = true
+		case r.tags[i-1].Is(TagTypeDelim) && strings.HasSuffix(r.tags[i-1].Delim(), "("):
+			return date
+		case !r.tags[i-1].Is(TagTypeText, TagTypeWhitespace, TagTypeDelim):
+			return false
+		}
+	}
+	return false
+}
+
 // delim fixes the delimiter based on surrounding tags.
 func (b *TagBuilder) delim(delim string, tags []Tag, i int, types ...TagType) string {
 	// special cases
@@ -1201,10 +2273,10 @@ func (b *TagBuilder) delim(delim string, tags []Tag, i int, types ...TagType) st
 		switch r {
 		case '-', '+', ',', '.', '~':
 			return r
-		case '\t', '\n', '\f', '\r', ' ', '_':
-			return ' '
+		case '(', ')', '[', ']', '{', '}', '/', '\\':
+			return -1
 		}
-		return -1
+		return ' '
 	}, delim), " ")
 	// bail if last tag or not a period
 	if s != "." || i == len(tags)-1 {
@@ -1287,6 +2359,16 @@ func min(a, b int) int {
 	return b
 }
 
+// skipDelims returns the position of the next non-delimiter tag after i,
+// and the skipped delimiters.
+func skipDelims(tags []Tag, i int) (int, string) {
+	var s string
+	for i++; i < len(tags) && tags[i].Is(TagTypeWhitespace, TagTypeDelim); i++ {
+		s += tags[i].Text()
+	}
+	return i, strings.TrimSpace(s)
+}
+
 // peek determines if i is of type.
 func peek(tags []Tag, i int, types ...TagType) bool {
 	return 0 <= i && i < len(tags) && tags[i].Is(types...)
@@ -0,0 +1,301 @@
+package rls
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LexerPattern is a named capture pattern for one of the pattern lexers (see
+// NewSeriesLexer, NewDiscSourceYearLexer, NewDiscLexer, NewDateLexer, and
+// NewVersionLexer).
+type LexerPattern struct {
+	// Kind is the lexer kind.
+	Kind string `json:"kind"`
+	// Pattern is the regexp, implicitly anchored to the start of the text.
+	Pattern string `json:"pattern"`
+	// Flags are the regexp flags (ie, `i` for case insensitive).
+	Flags string `json:"flags,omitempty"`
+	// Priority orders patterns of the same kind, lowest first.
+	Priority int `json:"priority,omitempty"`
+}
+
+// lexerPatternCaptures are the named capture groups allowed for each lexer
+// pattern kind.
+var lexerPatternCaptures = map[string][]string{
+	"series":         {"s", "e", "v", "d", "S", "m"},
+	"discsourceyear": {"d", "s", "y"},
+	"disc":           {"t", "c", "x", "z", "n"},
+	"date":           {"2006", "06", "01", "_1", "02", "_2", "Jan", "January", "YY"},
+	"version":        {"v", "V", "u", "b"},
+}
+
+// Regexp returns the regexp string for the pattern.
+func (pat LexerPattern) Regexp() string {
+	var s string
+	if pat.Flags != "" {
+		s = `(?` + pat.Flags + `)`
+	}
+	return s + `^` + pat.Pattern
+}
+
+// Validate validates the pattern.
+func (pat LexerPattern) Validate() error {
+	captures, ok := lexerPatternCaptures[pat.Kind]
+	switch {
+	case !ok:
+		return fmt.Errorf("invalid kind %q", pat.Kind)
+	case pat.Pattern == "":
+		return errors.New("must define pattern")
+	case strings.Trim(pat.Flags, "imsU") != "":
+		return fmt.Errorf("invalid flags %q", pat.Flags)
+	}
+	re, err := regexp.Compile(pat.Regexp())
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pat.Pattern, err)
+	}
+	var count int
+	for _, name := range re.SubexpNames() {
+		switch {
+		case name == "":
+		case !contains(captures, name):
+			return fmt.Errorf("pattern %q has invalid capture group %q for kind %q (must be one of %q)", pat.Pattern, name, pat.Kind, captures)
+		default:
+			count++
+		}
+	}
+	if count == 0 {
+		return fmt.Errorf("pattern %q must have at least one named capture group", pat.Pattern)
+	}
+	return nil
+}
+
+// DefaultLexerPatterns returns the default lexer patterns.
+func DefaultLexerPatterns() []LexerPattern {
+	var v []LexerPattern
+	for _, kind := range []struct {
+		kind string
+		strs []string
+	}{
+		{"series", []string{
+			// s02, S01E01
+			`s(?P<s>[0-8]?\d)[\-\._ ]?(?:e(?P<e>\d{1,5}))?\b`,
+			// S01E02E03, S01E02-E03, S01E03.E04.E05
+			`s(?P<s>[0-8]?\d)(?P<m>(?:[\-\._ ]?e\d{1,5}){1,5})\b`,
+			// S01S02S03
+			`(?P<S>(?:s[0-8]?\d){2,4})\b`,
+			// 2x1, 1x01
+			`(?P<s>[0-8]?\d)x(?P<e>\d{1,3})\b`,
+			// S01 - 02v3, S07-06, s03-5v.9
+			`s(?P<s>[0-8]?\d)[\-\._ ]{1,3}(?P<e>\d{1,5})(?:[\-\._ ]{1,3}(?P<v>v\d+(?:\.\d+){0,2}))?\b`,
+			// Season.01.Episode.02, Series.01.Ep.02, Series.01, Season.01
+			`(?:series|season|s)[\-\._ ]?(?P<s>[0-8]?\d)(?:[\-\._ ]?(?:episode|ep)(?P<e>\d{1,5}))?\b`,
+			// Vol.1.No.2, vol1no2
+			`vol(?:ume)?[\-\._ ]?(?P<s>\d{1,3})(?:[\-\._ ]?(?:number|no)[\-\._ ]?(?P<e>\d{1,5}))\b`,
+			// Episode 15, E009, Ep. 007, Ep.05-07
+			`e(?:p(?:isode)?[\-\._ ]{1,3})?(?P<e>\d{1,5})(?:[\-\._ ]{1,3}\d{1,3})?\b`,
+			// 10v1.7, 13v2
+			`(?P<e>\d{1,5})(?P<v>v[\-\._ ]?\d+(?:\.\d){0,2})\b`,
+			// S01.Disc02, s01D3, Series.01.Disc.02, S02DVD3
+			`(?:series|season|s)[\-\._ ]?(?P<s>[0-8]?\d)[\-\._ ]?(?P<d>(?:disc|disk|dvd|d)[\-\._ ]?(?:\d{1,3}))\b`,
+			// s1957e01
+			`s(?P<s>19\d\d)e(?P<e>\d{2,4})\b`,
+		}},
+		{"discsourceyear", []string{
+			// VLS2004, 2DVD1999, 4CD2003
+			`(?P<d>[2-9])?(?P<s>cd|ep|lp|dvd|vls|vinyl)(?P<y>(?:19|20)\d\d)\b`,
+			// WEB2007
+			`(?P<s>web)(?P<y>20\d\d)\b`,
+		}},
+		{"disc", []string{
+			// D01, Disc.1, Disc.2.of.4
+			`(?P<t>d)(?:is[ck][\-\._ ])?(?P<c>\d{1,3})(?:[\-\._ ]?of[\-\._ ]?(?P<n>\d{1,3}))?\b`,
+			// 12DiSCS
+			`(?P<c>\d{1,3})[\-\._ ]?di(?P<t>s)[ck]s?\b`,
+			// CD1, CD30, CD1of3
+			`(?P<t>cd)[\-\._ ]?(?P<c>\d{1,2})(?:[\-\._ ]?of[\-\._ ]?(?P<n>\d{1,2}))?\b`,
+			// DVD2, DVD24 -- does not match DVD5/DVD9
+			`(?P<t>dvd)[\-\._ ]?(?P<c>[1-46-8]|[12]\d)(?:[\-\._ ]?of[\-\._ ]?(?P<n>\d{1,2}))?\b`,
+			// 2xDVD9
+			`(?P<c>\d{1,2})(?P<t>x(?:dvd9))\b`,
+			// 2DVD9, 6DVD9
+			`(?P<c>[2-9])(?P<z>dvd9)\b`,
+			// 2xVinyl, 3xDVD, 4xCD
+			`(?P<c>\d{1,2})(?P<t>x(?:cd|ep|lp|dvda|dvd|vls|vinyl)s?)\b`,
+			// 2Vinyl, 6DVD
+			`(?P<c>\d{1,2})(?P<x>(?:cd|ep|lp|dvda|dvd|vls|vinyl)s?)\b`,
+			// CDS3
+			`(?:(?P<x>cd)s)(?P<c>\d{1,2})\b`,
+			// 2CDS
+			`(?P<c>[2-9])(?P<x>cds)\b`,
+		}},
+		{"date", []string{
+			// 2006-01-02, 2006
+			`(?P<2006>(?:19|20)\d{2})(?:[\-\._ ](?P<01>\d{2})[\-\._ ](?P<02>\d{2}))?\b`,
+			// 2006-01
+			`(?P<2006>(?:19|20)\d{2})?:[\-\._ ](?P<01>\d{2})\b`,
+			// 13-02-2006
+			`(?P<01>\d{2})[\-\._ ](?P<02>\d{2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
+			// 02-13-2006
+			`(?P<02>\d{2})[\-\._ ](?P<01>\d{2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
+			// 2nd Jan 2006, 13 Dec 2011, Nov 1999
+			`(?:(?P<_2>\d{1,2})(?:th|st|nd|rd)?[\-\._ ])?(?P<Jan>Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
+			// 01-August-1998
+			`(?P<_2>\d{1,2})[\-\._ ](?P<January>January|February|March|April|May|June|July|August|September|October|November|December)[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
+			// MAY-30-1992
+			`(?P<Jan>Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[\-\._ ](?P<_2>\d{1,2})[\-\._ ](?P<2006>(?:19|20)\d{2})\b`,
+			// 17.12.15, 20-9-9
+			`(?P<YY>[12]\d)[\-\._ ](?P<01>\d\d?)[\-\._ ](?P<02>\d\d?)\b`,
+		}},
+		{"version", []string{
+			// v1.17, v1, v1.2a, v1b
+			`(version[\-\._ ])?(?P<v>v[\-\._ ]?\d{1,2}(?:[\._ ]\d{1,2}[a-z]?\d*){0,3})\b`,
+			// v2012, v20120803, v20120803, v1999.08.08
+			`(version[\-\._ ])?(?P<v>v[\-\._ ]?(?:19|20)\d\d(?:[\-\._ ]?\d\d?){0,2})\b`,
+			// v60009
+			`(version[\-\._ ])?(?P<v>v[\-\._ ]?\d{4,10})\b`,
+			// Version 2004, Version 21H2, Version 22H1
+			`version[\-\._ ](?P<V>\d{2,}|\d{2}[a-z]{1,2}\d{1,2})\b`,
+			// 11.09.1, 100.000.99999999999, 23.3.2.458
+			`(?P<u>\d{1,3}\.\d{1,3}\.\d{1,16}(\.\d{1,16})?)\b`,
+			// Build 12345678, Build.8091234, Build 1234 (not Build 2019)
+			`build[\-\._ ]?(?P<b>\d{5,10}|(?:1[0-8]|2[1-9]|[03-9]\d)\d{2})\b`,
+		}},
+	} {
+		for i, s := range kind.strs {
+			v = append(v, LexerPattern{
+				Kind:     kind.kind,
+				Pattern:  s,
+				Flags:    "i",
+				Priority: (i + 1) * 10,
+			})
+		}
+	}
+	return v
+}
+
+// buildLexerPatterns validates and sorts the patterns by priority, grouping
+// the regexps by kind.
+func buildLexerPatterns(patterns ...LexerPattern) (map[string][]string, error) {
+	v := make([]LexerPattern, len(patterns))
+	copy(v, patterns)
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].Priority < v[j].Priority
+	})
+	m := make(map[string][]string)
+	for _, pat := range v {
+		if err := pat.Validate(); err != nil {
+			return nil, err
+		}
+		m[pat.Kind] = append(m[pat.Kind], pat.Regexp())
+	}
+	return m, nil
+}
+
+// LoadLexerPatterns loads lexer patterns from the reader. Patterns can either
+// be a JSON array of objects, or csv with the headers Kind, Pattern, Flags,
+// Priority. All loaded patterns are validated.
+func LoadLexerPatterns(rdr io.Reader) ([]LexerPattern, error) {
+	r := bufio.NewReader(rdr)
+	// sniff json
+	for {
+		c, _, err := r.ReadRune()
+		switch {
+		case err != nil && errors.Is(err, io.EOF):
+			return nil, errors.New("empty lexer patterns")
+		case err != nil:
+			return nil, err
+		case c == ' ', c == '\t', c == '\r', c == '\n', c == '\ufeff':
+			continue
+		}
+		if err := r.UnreadRune(); err != nil {
+			return nil, err
+		}
+		if c == '[' {
+			return loadLexerPatternsJSON(r)
+		}
+		return loadLexerPatternsCSV(r)
+	}
+}
+
+// loadLexerPatternsJSON loads json lexer patterns.
+func loadLexerPatternsJSON(r io.Reader) ([]LexerPattern, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var v []LexerPattern
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	for i, pat := range v {
+		if err := pat.Validate(); err != nil {
+			return nil, fmt.Errorf("pattern %d: %w", i, err)
+		}
+	}
+	return v, nil
+}
+
+// loadLexerPatternsCSV loads csv lexer patterns.
+func loadLexerPatternsCSV(rdr io.Reader) ([]LexerPattern, error) {
+	r := csv.NewReader(rdr)
+	r.FieldsPerRecord = 4
+	// check header
+	switch v, err := r.Read(); {
+	case err != nil:
+		return nil, err
+	case v[0] != "Kind" || v[1] != "Pattern" || v[2] != "Flags" || v[3] != "Priority":
+		return nil, errors.New("must have csv headers Kind, Pattern, Flags, Priority")
+	}
+	var patterns []LexerPattern
+	for i := 1; ; i++ {
+		v, err := r.Read()
+		switch {
+		case err != nil && errors.Is(err, io.EOF):
+			return patterns, nil
+		case err != nil:
+			return nil, err
+		}
+		pat := LexerPattern{
+			Kind:    v[0],
+			Pattern: v[1],
+			Flags:   v[2],
+		}
+		if v[3] != "" {
+			if pat.Priority, err = strconv.Atoi(v[3]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid priority %q", i+1, v[3])
+			}
+		}
+		if err := pat.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		patterns = append(patterns, pat)
+	}
+}
+
+// LoadLexerPatternsFile loads lexer patterns from a file.
+func LoadLexerPatternsFile(file string) ([]LexerPattern, error) {
+	f, err := os.OpenFile(file, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+	defer f.Close()
+	patterns, err := LoadLexerPatterns(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+	return patterns, nil
+}
+
+// LoadLexerPatternsBytes loads lexer patterns from buf.
+func LoadLexerPatternsBytes(buf []byte) ([]LexerPattern, error) {
+	return LoadLexerPatterns(bytes.NewReader(buf))
+}
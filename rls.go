@@ -3,16 +3,19 @@ package rls
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/moistari/rls/taginfo"
+	"golang.org/x/text/language"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 )
@@ -33,32 +36,83 @@ type Release struct {
 
 	Source     string
 	Resolution string
+	FrameRate  float64
+	HFR        bool
 	Collection string
+	Service    []string
+	Network    []string
+	Publisher  []string
 
 	Year  int
 	Month int
 	Day   int
 
-	Series  int
-	Episode int
-	Version string
-	Disc    string
+	Series    int
+	Episode   int
+	Version   string
+	Disc      string
+	DiscTotal int
+	Part      int
+	PartTotal int
+
+	AbsoluteEpisode    int
+	AbsoluteEpisodeEnd int
+	Batch              bool
 
 	Codec    []string
 	HDR      []string
+	BitDepth int
+	Chroma   string
+	ThreeD   ThreeD
 	Audio    []string
 	Channels string
+
+	AudioTracks  []AudioTrack
+	MusicQuality MusicQuality
+
 	Other    []string
 	Cut      []string
 	Edition  []string
 	Language []string
 
+	Revision        int
+	RevisionMarkers []string
+
+	Game     GameInfo
+	Software Software
+	Sports   SportsEvent
+
+	AudioLanguages    []string
+	SubtitleLanguages []string
+	HardcodedSubs     bool
+	LanguageCount     int
+
+	Label       string
+	Catalog     string
+	MusicFormat string
+
+	ISBN       string
+	BookFormat string
+	Narrator   string
+	Retail     bool
+	Unabridged bool
+
+	Volume     int
+	Issue      int
+	IssueEnd   int
+	IssueTotal int
+	Annual     int
+
 	Size      string
 	Region    string
 	Container string
 	Genre     string
 	ID        string
+	IMDbID    string
+	TMDbID    int
+	TVDbID    int
 	Group     string
+	Origin    Origin
 	Meta      []string
 	Site      string
 	Sum       string
@@ -66,10 +120,269 @@ type Release struct {
 	Req       bool
 	Ext       string
 
+	Extra map[string][]string
+
 	tags   []Tag
 	dates  []int
 	unused []int
 	end    int
+	pivot  int
+	last   int
+}
+
+// AudioTrack is release audio track information.
+type AudioTrack struct {
+	Codec        string
+	Channels     string
+	ChannelCount int
+	Object       bool
+	Language     string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (track AudioTrack) String() string {
+	var v []string
+	if track.Language != "" {
+		v = append(v, track.Language)
+	}
+	v = append(v, track.Codec)
+	if track.Channels != "" {
+		v = append(v, track.Channels)
+	}
+	if track.Object {
+		v = append(v, "object")
+	}
+	return strings.Join(v, " ")
+}
+
+// AudioTracks groups the audio, channels, and language tags in tags into
+// audio tracks. Codec audio tags start a new track, with adjacent channels,
+// extension audio tags (Atmos), and language tags added to the track.
+//
+// Audio tracks are grouped from the lexed tags instead of by NewAudioLexer, as
+// the channels and language tags adjacent to an audio tag are lexed by the
+// lexers that follow it. Use with the tags returned by a Parser's Parse.
+func AudioTracks(tags []Tag) []AudioTrack {
+	var tracks []AudioTrack
+	cur, lang, channels := -1, "", ""
+	for i := 0; i < len(tags); i++ {
+		switch tags[i].TagType() {
+		case TagTypeWhitespace, TagTypeDelim:
+		case TagTypeLanguage:
+			// markers (DL, MULTi) and subtitles are not a track language
+			if role := languageRole(tags[i]); role != "language" && role != "audio" {
+				continue
+			}
+			s := tags[i].Language()
+			if cur != -1 && tracks[cur].Language == "" && !peekAudioCodec(tags, i+1) {
+				tracks[cur].Language, cur = s, -1
+			} else if lang == "" {
+				lang = s
+			}
+		case TagTypeAudio:
+			s, kind := tags[i].Audio(), tags[i].InfoKind()
+			switch {
+			case strings.HasSuffix(kind, "codec"):
+				tracks = append(tracks, AudioTrack{
+					Codec:    s,
+					Object:   strings.HasPrefix(kind, "object-"),
+					Language: lang,
+				})
+				cur, lang = len(tracks)-1, ""
+				if channels != "" {
+					tracks[cur].Channels, channels = channels, ""
+				}
+			case strings.HasSuffix(kind, "extension") && cur != -1:
+				tracks[cur].Object = tracks[cur].Object || strings.HasPrefix(kind, "object-")
+			case strings.HasSuffix(kind, "extension"):
+				tracks = append(tracks, AudioTrack{
+					Codec:    s,
+					Object:   strings.HasPrefix(kind, "object-"),
+					Language: lang,
+				})
+				cur, lang = len(tracks)-1, ""
+			}
+		case TagTypeChannels:
+			if s := tags[i].Channels(); cur != -1 && tracks[cur].Channels == "" {
+				tracks[cur].Channels = s
+			} else {
+				channels, cur = s, -1
+			}
+		default:
+			cur, lang, channels = -1, "", ""
+		}
+	}
+	// channel counts
+	for i := range tracks {
+		for _, c := range tracks[i].Channels {
+			if '0' <= c && c <= '9' {
+				tracks[i].ChannelCount += int(c - '0')
+			}
+		}
+	}
+	return tracks
+}
+
+// peekAudioCodec returns true when the next non-delimiter tag at or after i is
+// a codec audio tag.
+func peekAudioCodec(tags []Tag, i int) bool {
+	for ; i < len(tags) && tags[i].Is(TagTypeWhitespace, TagTypeDelim); i++ {
+	}
+	return i < len(tags) && tags[i].Is(TagTypeAudio) && strings.HasSuffix(tags[i].InfoKind(), "codec")
+}
+
+// MusicQuality is music release quality information.
+type MusicQuality struct {
+	// Mode is the bitrate mode (CBR, VBR, Lossless).
+	Mode string
+	// Format is the audio format (FLAC, MP3).
+	Format string
+	// Preset is the LAME preset (V0, V2, APS).
+	Preset string
+	// Bitrate is the nominal bitrate, in Kbps.
+	Bitrate int
+	// SampleRate is the sample rate, in Hz.
+	SampleRate int
+	// BitDepth is the bit depth.
+	BitDepth int
+}
+
+// String satisfies the fmt.Stringer interface.
+func (q MusicQuality) String() string {
+	var v []string
+	for _, s := range []string{q.Format, q.Mode, q.Preset} {
+		if s != "" {
+			v = append(v, s)
+		}
+	}
+	if q.Bitrate != 0 {
+		v = append(v, strconv.Itoa(q.Bitrate)+"Kbps")
+	}
+	if q.BitDepth != 0 {
+		v = append(v, strconv.Itoa(q.BitDepth)+"bit")
+	}
+	if q.SampleRate != 0 {
+		v = append(v, strconv.FormatFloat(float64(q.SampleRate)/1000, 'f', -1, 64)+"kHz")
+	}
+	return strings.Join(v, " ")
+}
+
+// ThreeD is release 3D video information.
+type ThreeD struct {
+	// Stereoscopic is true for 3D releases.
+	Stereoscopic bool
+	// Layout is the frame layout (SBS, OU, MVC, Anaglyph).
+	Layout string
+	// Half is true for half resolution side-by-side and over-under layouts.
+	Half bool
+}
+
+// String satisfies the fmt.Stringer interface.
+func (d ThreeD) String() string {
+	switch {
+	case !d.Stereoscopic:
+		return ""
+	case d.Layout == "":
+		return "3D"
+	case d.Half:
+		return "3D Half-" + d.Layout
+	}
+	return "3D " + d.Layout
+}
+
+// GameInfo is game release information.
+type GameInfo struct {
+	// Update is true for update and patch releases (not the base game).
+	Update bool
+	// DLC is true for DLC releases.
+	DLC bool
+	// CrackOnly is true for crack and fix only releases.
+	CrackOnly bool
+	// Build is the build ID (Steam build ID).
+	Build int
+	// Included is the bundled content (DLC, Update, Crack, Bonus, Trainer).
+	Included []string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (game GameInfo) String() string {
+	var v []string
+	for _, z := range []struct {
+		b bool
+		s string
+	}{
+		{game.Update, "Update"},
+		{game.DLC, "DLC"},
+		{game.CrackOnly, "CrackOnly"},
+	} {
+		if z.b {
+			v = append(v, z.s)
+		}
+	}
+	if game.Build != 0 {
+		v = append(v, "Build."+strconv.Itoa(game.Build))
+	}
+	for _, s := range game.Included {
+		v = append(v, "Incl."+s)
+	}
+	return strings.Join(v, " ")
+}
+
+// Software is software release information.
+type Software struct {
+	// MinOS is the minimum operating system version (MacOS 12).
+	MinOS string
+	// Editions are the editions (Pro, Enterprise, LTSC).
+	Editions []string
+	// Portable is true for portable releases.
+	Portable bool
+	// Tools are the bundled tools (Keygen, Serial, Patch, Crack).
+	Tools []string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (software Software) String() string {
+	var v []string
+	if software.MinOS != "" {
+		v = append(v, software.MinOS+"+")
+	}
+	v = append(v, software.Editions...)
+	if software.Portable {
+		v = append(v, "Portable")
+	}
+	for _, s := range software.Tools {
+		v = append(v, "Incl."+s)
+	}
+	return strings.Join(v, " ")
+}
+
+// SportsEvent is sports event information.
+type SportsEvent struct {
+	// League is the league or competition (NFL, Formula1, UFC).
+	League string
+	// Sport is the sport (football, motorsport, mma).
+	Sport string
+	// RoundType is the round type (Round, Week, Matchday, Event).
+	RoundType string
+	// Round is the round, week, matchday, or event number.
+	Round int
+	// Teams are the competing teams or competitors (home, away).
+	Teams []string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (event SportsEvent) String() string {
+	var v []string
+	if event.League != "" {
+		v = append(v, event.League)
+	}
+	if event.Round != 0 {
+		v = append(v, event.RoundType+" "+strconv.Itoa(event.Round))
+	}
+	if len(event.Teams) != 0 {
+		v = append(v, strings.Join(event.Teams, " vs "))
+	}
+	return strings.Join(v, ", ")
 }
 
 // Parse creates a release from src.
@@ -175,6 +488,29 @@ func (r Release) Dates() []Tag {
 	return dates
 }
 
+// LanguageTags returns the BCP 47 language tags for the release languages. The
+// language tag's region carries variant details, such as fr-FR for TRUEFRENCH
+// or fr-CA for VFQ.
+func (r Release) LanguageTags() []language.Tag {
+	var v []language.Tag
+	for _, tag := range r.tags {
+		if t, ok := tag.LanguageTag(); ok && !containsTag(v, t) {
+			v = append(v, t)
+		}
+	}
+	return v
+}
+
+// containsTag returns true when v contains t.
+func containsTag(v []language.Tag, t language.Tag) bool {
+	for _, u := range v {
+		if u == t {
+			return true
+		}
+	}
+	return false
+}
+
 // Tag is a release tag.
 type Tag struct {
 	typ   TagType
@@ -283,6 +619,14 @@ func (tag Tag) InfoExcl() bool {
 	return false
 }
 
+// InfoKind returns the associated tag info kind.
+func (tag Tag) InfoKind() string {
+	if info := tag.Info(); info != nil {
+		return info.Kind()
+	}
+	return ""
+}
+
 // TagType returns the tag's tag type.
 func (tag Tag) TagType() TagType {
 	return tag.typ
@@ -351,6 +695,9 @@ func (tag Tag) Normalize() string {
 		}
 		return fmt.Sprintf("S%02d", series)
 	case TagTypeVersion:
+		if build := tag.Build(); build != 0 {
+			return "Build." + strconv.Itoa(build)
+		}
 		return tag.Version()
 	case TagTypeDisc:
 		return tag.Disc()
@@ -395,6 +742,33 @@ func (tag Tag) Normalize() string {
 		return "[[" + typ + ":" + s + "]]"
 	case TagTypeExt:
 		return tag.Ext()
+	case TagTypeBitDepth:
+		return tag.normalize(tag.v[1], tag.v[2:]...)
+	case TagTypeChroma:
+		return tag.Chroma()
+	case TagTypeFrameRate:
+		if f := tag.FrameRate(); f != 0 {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return tag.normalize(tag.v[1], tag.v[2:]...)
+	case TagTypeISBN:
+		return tag.ISBN()
+	case TagTypeIssue:
+		return tag.Issue()
+	case TagTypeLeague:
+		return tag.League()
+	case TagTypeRound:
+		kind, round := tag.Round()
+		return kind + " " + strconv.Itoa(round)
+	case TagTypePart:
+		part, total := tag.Part()
+		if total != 0 {
+			return fmt.Sprintf("Part %d of %d", part, total)
+		}
+		return "Part " + strconv.Itoa(part)
+	}
+	if tag.typ.Custom() {
+		return tag.Extra()
 	}
 	return ""
 }
@@ -437,10 +811,10 @@ func (tag Tag) Format(f fmt.State, verb rune) {
 	case 'o':
 		buf = append(buf, tag.v[0]...)
 	case 'v':
-		buf = append(buf, fmt.Sprintf("%s:%q", tag.typ, tag.v[1:])...)
+		buf = append(buf, fmt.Sprintf("%s:%q", tag.typ.Name(), tag.v[1:])...)
 	case 'e':
 		s := strconv.Quote(tag.Normalize())
-		buf = append(buf, "<"+tag.typ.String()+":"+s[1:len(s)-1]+">"...)
+		buf = append(buf, "<"+tag.typ.Name()+":"+s[1:len(s)-1]+">"...)
 	case 's', 'r':
 		buf = append(buf, tag.Normalize()...)
 	}
@@ -460,7 +834,7 @@ func (tag Tag) Delim() string {
 // Text normalizes the text value.
 func (tag Tag) Text() string {
 	switch tag.prev {
-	case TagTypeDate, TagTypeSeries:
+	case TagTypeDate, TagTypeSeries, TagTypeRound, TagTypePart:
 		return tag.v[0]
 	case TagTypeChannels:
 		return tag.Channels()
@@ -482,6 +856,14 @@ func (tag Tag) Platform() string {
 	return tag.normalize(tag.v[1], tag.v[2:]...)
 }
 
+// PlatformVersion normalizes the minimum platform version value.
+func (tag Tag) PlatformVersion() string {
+	if len(tag.v) < 3 {
+		return ""
+	}
+	return tag.v[2]
+}
+
 // Arch normalizes the arch value.
 func (tag Tag) Arch() string {
 	return tag.normalize(tag.v[1], tag.v[2:]...)
@@ -533,6 +915,15 @@ func (tag Tag) Version() string {
 	return tag.v[1]
 }
 
+// Build normalizes the build value.
+func (tag Tag) Build() int {
+	if len(tag.v) < 3 {
+		return 0
+	}
+	build, _ := strconv.Atoi(tag.v[2])
+	return build
+}
+
 // Disc normmalizes the disc value.
 func (tag Tag) Disc() string {
 	disc, _ := strconv.Atoi(tag.v[2])
@@ -547,6 +938,15 @@ func (tag Tag) Disc() string {
 	return fmt.Sprintf("D%02d", disc)
 }
 
+// DiscTotal normalizes the disc total value (`Disc.2.of.4`, `CD1of3`).
+func (tag Tag) DiscTotal() int {
+	if len(tag.v) < 4 {
+		return 0
+	}
+	total, _ := strconv.Atoi(tag.v[3])
+	return total
+}
+
 // Codec normalizes a codec value.
 func (tag Tag) Codec() string {
 	return tag.normalize(tag.v[1], tag.v[2:]...)
@@ -599,6 +999,23 @@ func (tag Tag) Language() string {
 	return tag.normalize(tag.v[1], tag.v[2:]...)
 }
 
+// LanguageTag returns the BCP 47 language tag for the language value, as
+// defined by the tag info code.
+func (tag Tag) LanguageTag() (language.Tag, bool) {
+	if !tag.typ.Is(TagTypeLanguage) {
+		return language.Und, false
+	}
+	info := tag.Info()
+	if info == nil || info.Code() == "" {
+		return language.Und, false
+	}
+	t, err := language.Parse(info.Code())
+	if err != nil {
+		return language.Und, false
+	}
+	return t, true
+}
+
 // Size normalizes the size value.
 func (tag Tag) Size() string {
 	return strings.ReplaceAll(strings.ToUpper(tag.normalize(tag.v[1], tag.v[2:]...)), "I", "i")
@@ -639,6 +1056,77 @@ func (tag Tag) Ext() string {
 	return strings.ToLower(tag.v[1])
 }
 
+// BitDepth normalizes a bit depth value (8, 10, 12).
+func (tag Tag) BitDepth() int {
+	i, _ := strconv.Atoi(strings.TrimSuffix(tag.normalize(tag.v[1], tag.v[2:]...), "bit"))
+	return i
+}
+
+// Chroma normalizes a chroma subsampling value.
+func (tag Tag) Chroma() string {
+	return tag.normalize(tag.v[1], tag.v[2:]...)
+}
+
+// HFR returns true when the tag is a high frame rate (`HFR`, or 48 fps and
+// above).
+func (tag Tag) HFR() bool {
+	return strings.EqualFold(tag.v[1], "HFR") || 48 <= tag.FrameRate()
+}
+
+// FrameRate normalizes a frame rate value. Returns 0 for a high frame rate
+// without a frame rate (`HFR`).
+func (tag Tag) FrameRate() float64 {
+	f, _ := strconv.ParseFloat(strings.Map(func(r rune) rune {
+		if r == '_' || r == ',' {
+			return '.'
+		}
+		return r
+	}, tag.v[1]), 64)
+	return f
+}
+
+// ISBN normalizes an ISBN value, removing separators.
+func (tag Tag) ISBN() string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case '0' <= r && r <= '9':
+			return r
+		case r == 'x' || r == 'X':
+			return 'X'
+		}
+		return -1
+	}, tag.v[1])
+}
+
+// Issue normalizes an issue value.
+func (tag Tag) Issue() string {
+	return tag.v[1]
+}
+
+// League normalizes the league value.
+func (tag Tag) League() string {
+	return tag.normalize(tag.v[1], tag.v[2:]...)
+}
+
+// Round normalizes the round value, returning the round kind (Round, Week,
+// Matchday, Event) and number.
+func (tag Tag) Round() (string, int) {
+	round, _ := strconv.Atoi(tag.v[2])
+	return tag.v[1], round
+}
+
+// Part normalizes the part value, returning the part and total.
+func (tag Tag) Part() (int, int) {
+	part, _ := strconv.Atoi(tag.v[1])
+	total, _ := strconv.Atoi(tag.v[2])
+	return part, total
+}
+
+// Extra normalizes a custom tag type value.
+func (tag Tag) Extra() string {
+	return tag.normalize(tag.v[1], tag.v[2:]...)
+}
+
 // TagType is a tag type.
 type TagType int
 
@@ -672,8 +1160,73 @@ const (
 	TagTypeGroup
 	TagTypeMeta
 	TagTypeExt
+	TagTypeBitDepth
+	TagTypeChroma
+	TagTypeFrameRate
+	TagTypeISBN
+	TagTypeIssue
+	TagTypeLeague
+	TagTypeRound
+	TagTypePart
 )
 
+// tagTypeCustom is the first custom tag type.
+const tagTypeCustom = TagType(len(_TagType_index) - 1)
+
+// customTagTypes are the registered custom tag type names.
+var customTagTypes struct {
+	sync.RWMutex
+	names []string
+}
+
+// RegisterTagType registers a custom tag type, returning the tag type.
+// Returns the previously registered tag type when name is already registered.
+// Safe for concurrent use.
+//
+// Custom tag types use the tag info of the lower cased name (see
+// NewRegexpLexer), and the normalized values of tags with a custom tag type
+// are collected in the release's Extra using the lower cased name as the key.
+func RegisterTagType(name string) TagType {
+	for typ := TagTypeWhitespace; typ < tagTypeCustom; typ++ {
+		if typ.Name() == name {
+			return typ
+		}
+	}
+	customTagTypes.Lock()
+	defer customTagTypes.Unlock()
+	for i, s := range customTagTypes.names {
+		if s == name {
+			return tagTypeCustom + TagType(i)
+		}
+	}
+	customTagTypes.names = append(customTagTypes.names, name)
+	return tagTypeCustom + TagType(len(customTagTypes.names)-1)
+}
+
+// Name returns the tag type name, including the names of registered custom
+// tag types.
+func (typ TagType) Name() string {
+	if typ < tagTypeCustom {
+		return typ.String()
+	}
+	customTagTypes.RLock()
+	defer customTagTypes.RUnlock()
+	if i := int(typ - tagTypeCustom); i < len(customTagTypes.names) {
+		return customTagTypes.names[i]
+	}
+	return typ.String()
+}
+
+// Custom returns true when the tag type is a registered custom tag type.
+func (typ TagType) Custom() bool {
+	if typ < tagTypeCustom {
+		return false
+	}
+	customTagTypes.RLock()
+	defer customTagTypes.RUnlock()
+	return int(typ-tagTypeCustom) < len(customTagTypes.names)
+}
+
 // Is returns true when tag type is in types.
 func (typ TagType) Is(types ...TagType) bool {
 	for _, t := range types {
@@ -701,66 +1254,118 @@ const (
 	Movie
 	Music
 	Series
+	Sports
+	// typeCustom is the first custom release type.
+	typeCustom
 )
 
+// typeNames are the release type names.
+var typeNames = []string{
+	"",
+	"app",
+	"audiobook",
+	"book",
+	"comic",
+	"education",
+	"episode",
+	"game",
+	"magazine",
+	"movie",
+	"music",
+	"series",
+	"sports",
+}
+
+// customTypes are the registered custom release types.
+var customTypes struct {
+	sync.RWMutex
+	names   []string
+	compare []int
+}
+
+// RegisterType registers a custom release type, returning the release type.
+// The compare value is the release type's Compare precedence, used when the
+// release type is not in CompareMap. Returns an error when name is empty or
+// is already a registered release type. Safe for concurrent use.
+//
+// Custom release types are registered with the taginfo package, and must be
+// registered prior to loading any tag info that uses the release type. Tags
+// with tag info having a custom release type mark the release as that type.
+func RegisterType(name string, compare int) (Type, error) {
+	if name == "" {
+		return Unknown, errors.New("must define release type name")
+	}
+	customTypes.Lock()
+	defer customTypes.Unlock()
+	if typ := parseType(name); typ != Unknown {
+		return Unknown, fmt.Errorf("release type %q already registered", name)
+	}
+	customTypes.names, customTypes.compare = append(customTypes.names, name), append(customTypes.compare, compare)
+	typ := typeCustom + Type(len(customTypes.names)-1)
+	taginfo.RegisterType(name, int(typ))
+	return typ, nil
+}
+
 // ParseType parses a type from s.
 func ParseType(s string) Type {
-	switch s {
-	case "app":
-		return App
-	case "audiobook":
-		return Audiobook
-	case "book":
-		return Book
-	case "comic":
-		return Comic
-	case "education":
-		return Education
-	case "episode":
-		return Episode
-	case "game":
-		return Game
-	case "magazine":
-		return Magazine
-	case "movie":
-		return Movie
-	case "music":
-		return Music
-	case "series":
-		return Series
+	customTypes.RLock()
+	defer customTypes.RUnlock()
+	return parseType(s)
+}
+
+// parseType parses a type from s. The caller must hold the custom types
+// lock.
+func parseType(s string) Type {
+	for i := 1; i < len(typeNames); i++ {
+		if typeNames[i] == s {
+			return Type(i)
+		}
+	}
+	for i, name := range customTypes.names {
+		if name == s {
+			return typeCustom + Type(i)
+		}
 	}
 	return Unknown
 }
 
 // String satisfies the fmt.Stringer interface.
 func (typ Type) String() string {
-	switch typ {
-	case App:
-		return "app"
-	case Audiobook:
-		return "audiobook"
-	case Book:
-		return "book"
-	case Comic:
-		return "comic"
-	case Education:
-		return "education"
-	case Episode:
-		return "episode"
-	case Game:
-		return "game"
-	case Magazine:
-		return "magazine"
-	case Movie:
-		return "movie"
-	case Music:
-		return "music"
-	case Series:
-		return "series"
+	if 0 <= typ && typ < typeCustom {
+		return typeNames[typ]
+	}
+	customTypes.RLock()
+	defer customTypes.RUnlock()
+	if i := int(typ - typeCustom); 0 <= i && i < len(customTypes.names) {
+		return customTypes.names[i]
 	}
 	return ""
 }
 
+// Custom returns true when the type is a registered custom release type.
+func (typ Type) Custom() bool {
+	if typ < typeCustom {
+		return false
+	}
+	customTypes.RLock()
+	defer customTypes.RUnlock()
+	return int(typ-typeCustom) < len(customTypes.names)
+}
+
+// precedence returns the release type's compare precedence from CompareMap,
+// or the compare value of a registered custom release type.
+func (typ Type) precedence() int {
+	if i, ok := CompareMap[typ]; ok || typ < typeCustom {
+		return i
+	}
+	customTypes.RLock()
+	defer customTypes.RUnlock()
+	if i := int(typ - typeCustom); i < len(customTypes.compare) {
+		return customTypes.compare[i]
+	}
+	return 0
+}
+
 // Is returns true when the type is in types.
 func (typ Type) Is(types ...Type) bool {
 	for _, t := range types {
@@ -771,6 +1376,43 @@ func (typ Type) Is(types ...Type) bool {
 	return false
 }
 
+// Origin is a release origin.
+type Origin int
+
+// Release origins.
+const (
+	OriginUnknown Origin = iota
+	OriginScene
+	OriginP2P
+	OriginInternal
+)
+
+// originNames are the release origin names.
+var originNames = []string{
+	"",
+	"scene",
+	"p2p",
+	"internal",
+}
+
+// ParseOrigin parses an origin from s.
+func ParseOrigin(s string) Origin {
+	for i := 1; i < len(originNames); i++ {
+		if originNames[i] == s {
+			return Origin(i)
+		}
+	}
+	return OriginUnknown
+}
+
+// String satisfies the fmt.Stringer interface.
+func (origin Origin) String() string {
+	if 0 <= origin && int(origin) < len(originNames) {
+		return originNames[origin]
+	}
+	return ""
+}
+
 // Builder is the interface for release builders.
 type Builder interface {
 	Build([]Tag, int) Release
@@ -794,8 +1436,8 @@ var DefaultBuilder Builder
 var DefaultParser Parser
 
 func init() {
-	for i := Unknown; i <= Series; i++ {
-		taginfo.RegisterType(i.String(), int(i))
+	for i, name := range typeNames {
+		taginfo.RegisterType(name, i)
 	}
 	DefaultBuilder = NewTagBuilder()
 	DefaultParser = NewDefaultParser()
@@ -803,7 +1445,7 @@ func init() {
 
 // CompareMap is the release compare map. Modifying this will alter the order
 // by which different release types are grouped together when using Compare
-// with a sort operation.
+// with a sort operation. Not safe for concurrent modification.
 var CompareMap = map[Type]int{
 	Unknown:   0,
 	Movie:     1,
@@ -817,15 +1459,17 @@ var CompareMap = map[Type]int{
 	Comic:     9,
 	Education: 8,
 	Magazine:  10,
+	Sports:    11,
 }
 
 // Compare compares a to b, normalizing titles with Normalize, comparing the
 // resulting lower cased strings. Release types are grouped together based on
-// the precedence defined in CompareMap.
+// the precedence defined in CompareMap. Titles are always split using
+// DefaultDelims.
 func Compare(a, b Release) int {
 	var cmp int
 	for _, f := range []func() int{
-		compareInt(CompareMap[a.Type], CompareMap[b.Type]),
+		compareInt(a.Type.precedence(), b.Type.precedence()),
 		compareTitle(a.Artist, b.Artist),
 		compareTitle(a.Title, b.Title),
 		compareInt(a.Year, b.Year),
@@ -836,6 +1480,8 @@ func Compare(a, b Release) int {
 		compareTitle(a.Subtitle, b.Subtitle),
 		compareTitle(a.Alt, b.Alt),
 		compareIntString(a.Resolution, b.Resolution),
+		compareInt(a.BitDepth, b.BitDepth),
+		compareString(a.ThreeD.String(), b.ThreeD.String()),
 		compareString(a.Version, b.Version),
 		compareString(a.Group, b.Group),
 		compareString(a.Title, b.Title),
@@ -848,6 +1494,30 @@ func Compare(a, b Release) int {
 	return cmp
 }
 
+// musicModes are the music quality mode ranks.
+var musicModes = map[string]int{
+	"CBR":      1,
+	"VBR":      2,
+	"Lossless": 3,
+}
+
+// CompareMusicQuality compares the music quality of a to b, ranking lossless
+// above VBR above CBR, followed by bit depth, sample rate, and bitrate.
+func CompareMusicQuality(a, b Release) int {
+	var cmp int
+	for _, f := range []func() int{
+		compareInt(musicModes[a.MusicQuality.Mode], musicModes[b.MusicQuality.Mode]),
+		compareInt(a.MusicQuality.BitDepth, b.MusicQuality.BitDepth),
+		compareInt(a.MusicQuality.SampleRate, b.MusicQuality.SampleRate),
+		compareInt(a.MusicQuality.Bitrate, b.MusicQuality.Bitrate),
+	} {
+		if cmp = f(); cmp != 0 {
+			return cmp
+		}
+	}
+	return cmp
+}
+
 // compareInt returns a func that compares a, b.
 func compareInt(a, b int) func() int {
 	return func() int {
@@ -898,8 +1568,13 @@ func compareIntString(a, b string) func() int {
 }
 
 // compareTitle returns a func that does a title comparison of a, b.
+//
+// Titles are split on the default delimiters, as Compare has no access to
+// the delimiters of the parser that produced a, b. Titles from a parser
+// configured with WithDelims may therefore split differently.
 func compareTitle(a, b string) func() int {
 	// const cutset = "\t\n\f\r -._,()[]{}+\\/~"
+	delims := DefaultDelims()
 	return func() int {
 		switch {
 		case a == b:
@@ -910,7 +1585,7 @@ func compareTitle(a, b string) func() int {
 			return 1
 		}
 		a, b := MustNormalize(a), MustNormalize(b)
-		av, bv := strings.FieldsFunc(strings.ToLower(a), isBreakDelim), strings.FieldsFunc(strings.ToLower(b), isBreakDelim)
+		av, bv := strings.FieldsFunc(strings.ToLower(a), delims.IsBreak), strings.FieldsFunc(strings.ToLower(b), delims.IsBreak)
 		start, min := 0, 3
 		if len(av) > 0 && len(bv) > 0 && av[0] == bv[0] && contains([]string{"a", "an", "the"}, av[0]) {
 			start, min = 1, 1
@@ -976,33 +1651,47 @@ func compareOriginal(a, b Release) func() int {
 	}
 }
 
-// isAnyDelim returns true if r is any delimiter.
-func isAnyDelim(r rune) bool {
-	switch r {
-	case '\t', '\n', '\f', '\r', ' ', '(', ')', '+', ',', '-', '.', '_', '[', '/', '\\', ']', '{', '}', '~':
-		return true
+// Delims are the delimiter rune sets used by the tag parser, its lexers and
+// the tag builder.
+type Delims struct {
+	// Any are all delimiters.
+	Any string
+	// Break are the break delimiters, used to split words.
+	Break string
+	// TitleTrim are the delimiters trimmed from titles.
+	TitleTrim string
+	// Group are the runes invalid in a group name.
+	Group string
+	// Work are the runes replaced with spaces in the parser's working buffer.
+	// Any delimiters not in the default set are also replaced, so that lexers
+	// match them the same as a space.
+	Work string
+}
+
+// DefaultDelims returns the default delimiters.
+func DefaultDelims() Delims {
+	return Delims{
+		Any:       "\t\n\f\r ()+,-._[/\\]{}~",
+		Break:     "\t\n\f\r ()+,._[/\\]{}~",
+		TitleTrim: "\t\n\f\r (),-_[/\\]{}~",
+		Group:     " _.()[]{}+",
+		Work:      "_,+",
 	}
-	return false
 }
 
-// isBreakDelim returns true if r is a break delimiter (same as any, excluding
-// '-').
-func isBreakDelim(r rune) bool {
-	switch r {
-	case '\t', '\n', '\f', '\r', ' ', '(', ')', '+', ',' /*, '-'*/, '.', '_', '[', '/', '\\', ']', '{', '}', '~':
-		return true
-	}
-	return false
+// IsAny returns true if r is any delimiter.
+func (d Delims) IsAny(r rune) bool {
+	return strings.ContainsRune(d.Any, r)
 }
 
-// isTitleTrimDelim returns true if r is a title trim delimiter (any delim
-// except '.', '+').
-func isTitleTrimDelim(r rune) bool {
-	switch r {
-	case '\t', '\n', '\f', '\r', ' ', '(', ')' /*, '+'*/, ',', '-' /*, '.'*/, '_', '[', '/', '\\', ']', '{', '}', '~':
-		return true
-	}
-	return false
+// IsBreak returns true if r is a break delimiter.
+func (d Delims) IsBreak(r rune) bool {
+	return strings.ContainsRune(d.Break, r)
+}
+
+// IsTitleTrim returns true if r is a title trim delimiter.
+func (d Delims) IsTitleTrim(r rune) bool {
+	return strings.ContainsRune(d.TitleTrim, r)
 }
 
 // convNumber attempts to convert a int or roman numeral.
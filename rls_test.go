@@ -11,16 +11,18 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/moistari/rls/taginfo"
+	"golang.org/x/text/language"
 	"golang.org/x/text/transform"
 )
 
 func TestParseRelease(t *testing.T) {
-	p := NewTagParser(taginfo.All(groupInfos()), DefaultLexers()...)
+	parsers := testParsers(t)
 	m := make(map[string]bool)
 	for n, tt := range rlsTests(t) {
 		i, test := n, tt
@@ -34,6 +36,10 @@ func TestParseRelease(t *testing.T) {
 				t.Fatalf("test %d %q is a duplicate!", i, test.s)
 			}
 			m[test.s] = true
+			p, ok := parsers[test.exp.Parser]
+			if !ok {
+				t.Fatalf("test %d %q has unknown parser %q", i, test.s, test.exp.Parser)
+			}
 			r := p.ParseRelease([]byte(test.s))
 			if test.s != "" && r.Tags() == nil {
 				t.Fatalf("test %d %q expected tags, got nil", i, test.s)
@@ -51,6 +57,7 @@ func TestParseRelease(t *testing.T) {
 				t.Fatalf("test %d %q has TagTypeDate count > 1: %d", i, test.s, count)
 			}
 			v := buildRls(r)
+			v.Parser = test.exp.Parser
 			if !cmp.Equal(v, test.exp) {
 				t.Errorf("test %d %q expected to be same, got:\n%s", i, test.s, cmp.Diff(test.exp, v))
 			}
@@ -59,6 +66,277 @@ func TestParseRelease(t *testing.T) {
 	}
 }
 
+func TestStages(t *testing.T) {
+	b := NewTagBuilder()
+	var names []string
+	for _, stage := range b.Stages() {
+		names = append(names, stage.Name)
+	}
+	if s, exp := strings.Join(names, ","), "fixFirstDate,pivot,fixFirst,fixBad,fixNoText,fixIsolated,fixMusic,collect,inspect,languages,music,game,specialDate,issues,unset,threeD,origin,titles,unused"; s != exp {
+		t.Errorf("expected stages %q, got: %q", exp, s)
+	}
+	if err := b.RemoveStage("fixMusic"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := b.InsertStage("", aliasStage); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := b.RemoveStage("missing"); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if stages := b.Stages(); stages[len(stages)-1].Name != "alias" {
+		t.Errorf("expected last stage %q, got: %q", "alias", stages[len(stages)-1].Name)
+	}
+	// default builder is unaffected
+	if r := ParseString("Artist-Album Bootleg-WEB-2020-GRP"); r.Title != "Album Bootleg" || r.Group != "GRP" {
+		t.Errorf("expected default builder to be unchanged, got: %q %q", r.Title, r.Group)
+	}
+}
+
+func TestLoadTitles(t *testing.T) {
+	titles, err := LoadTitles(strings.NewReader("# titles\nThe Limited Series\n\nThe Proper Extended Cut\nSpider-Man: No Way Home\nThe Proper\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := strings.Join(titles, ","), "The Limited Series,The Proper Extended Cut,Spider-Man: No Way Home,The Proper"; s != exp {
+		t.Errorf("expected titles %q, got: %q", exp, s)
+	}
+}
+
+func TestLanguageCodes(t *testing.T) {
+	for _, info := range taginfo.All()["language"] {
+		if code := info.Code(); code != "" {
+			if _, err := language.Parse(code); err != nil {
+				t.Errorf("language %q has invalid code %q: %v", info.Tag(), code, err)
+			}
+		}
+	}
+}
+
+func TestValidISBN(t *testing.T) {
+	for i, test := range []struct {
+		s   string
+		exp bool
+	}{
+		{"0306406152", true},
+		{"0306406153", false},
+		{"080442957X", true},
+		{"9780306406157", true},
+		{"9780306406158", false},
+		{"978030640615", false},
+		{"97803064061X7", false},
+	} {
+		if ok := ValidISBN(test.s); ok != test.exp {
+			t.Errorf("test %d expected %t, got: %t", i, test.exp, ok)
+		}
+	}
+}
+
+func TestValidIMDbID(t *testing.T) {
+	for i, test := range []struct {
+		s   string
+		exp bool
+	}{
+		{"tt0133093", true},
+		{"tt10872600", true},
+		{"tt013309", false},
+		{"tt133093X", false},
+		{"nm0000206", false},
+		{"tt0000000", false},
+	} {
+		if ok := ValidIMDbID(test.s); ok != test.exp {
+			t.Errorf("test %d expected %t, got: %t", i, test.exp, ok)
+		}
+	}
+}
+
+func TestLoadLexerPatterns(t *testing.T) {
+	for i, test := range []struct {
+		s   string
+		exp string
+	}{
+		{"Kind,Pattern,Flags,Priority\nseries,\"folge[\\-\\._ ]?(?P<e>\\d{1,4})\\b\",i,5\n", ""},
+		{`[{"kind": "series", "pattern": "folge[\\-\\._ ]?(?P<e>\\d{1,4})\\b", "flags": "i", "priority": 5}]`, ""},
+		{"", "empty lexer patterns"},
+		{"Kind,Pattern\n", "record on line 1: wrong number of fields"},
+		{"Type,Pattern,Flags,Priority\n", "must have csv headers Kind, Pattern, Flags, Priority"},
+		{"Kind,Pattern,Flags,Priority\nfoo,(?P<e>\\d),,\n", "line 2: invalid kind \"foo\""},
+		{"Kind,Pattern,Flags,Priority\nseries,(?P<e>\\d),,x\n", "line 2: invalid priority \"x\""},
+		{"Kind,Pattern,Flags,Priority\nseries,(?P<e>\\d),x,\n", "line 2: invalid flags \"x\""},
+		{"Kind,Pattern,Flags,Priority\ndate,(?P<e>\\d),,\n", "line 2: pattern \"(?P<e>\\\\d)\" has invalid capture group \"e\" for kind \"date\" (must be one of [\"2006\" \"06\" \"01\" \"_1\" \"02\" \"_2\" \"Jan\" \"January\" \"YY\"])"},
+		{"Kind,Pattern,Flags,Priority\nversion,(\\d,,\n", "line 2: invalid pattern \"(\\\\d\": error parsing regexp: missing closing ): `^(\\d`"},
+		{"Kind,Pattern,Flags,Priority\nversion,(\\d),,\n", "line 2: pattern \"(\\\\d)\" must have at least one named capture group"},
+		{`[{"kind": "series", "pattern": "(?P<x>\\d)"}]`, "pattern 0: pattern \"(?P<x>\\\\d)\" has invalid capture group \"x\" for kind \"series\" (must be one of [\"s\" \"e\" \"v\" \"d\" \"S\" \"m\"])"},
+	} {
+		patterns, err := LoadLexerPatternsBytes([]byte(test.s))
+		switch {
+		case test.exp == "" && err != nil:
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		case test.exp != "" && err == nil:
+			t.Fatalf("test %d expected error %q", i, test.exp)
+		case test.exp != "":
+			if s := err.Error(); s != test.exp {
+				t.Errorf("test %d expected error %q, got: %q", i, test.exp, s)
+			}
+			continue
+		}
+		lexers, err := DefaultLexersWithPatterns(patterns...)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		p := NewTagParser(taginfo.All(), lexers...)
+		const s = "Tatort.Folge.1071.German.1080p.WEB.x264-GRP"
+		if r := p.ParseRelease([]byte(s)); r.Title != "Tatort" || r.Episode != 1071 || r.Type != Episode {
+			t.Errorf("test %d expected Tatort episode 1071, got: %q %d %s", i, r.Title, r.Episode, r.Type)
+		}
+		if r := ParseString(s); r.Episode != 0 {
+			t.Errorf("test %d expected default parser to not parse episode, got: %d", i, r.Episode)
+		}
+	}
+}
+
+func TestDefaultLexersWithPatterns(t *testing.T) {
+	for i, test := range []struct {
+		pat LexerPattern
+		exp string
+	}{
+		{LexerPattern{Kind: "series", Pattern: `(?P<e>\d`}, "invalid pattern \"(?P<e>\\\\d\": error parsing regexp: missing closing ): `^(?P<e>\\d`"},
+		{LexerPattern{Kind: "episode", Pattern: `(?P<e>\d)`}, "invalid kind \"episode\""},
+		{LexerPattern{Kind: "disc", Pattern: `(?P<t>box)(?P<c>\d)\b`, Flags: "i"}, ""},
+	} {
+		lexers, err := DefaultLexersWithPatterns(test.pat)
+		switch {
+		case test.exp == "" && err != nil:
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		case test.exp != "" && err == nil:
+			t.Fatalf("test %d expected error %q", i, test.exp)
+		case test.exp != "":
+			if s := err.Error(); s != test.exp {
+				t.Errorf("test %d expected error %q, got: %q", i, test.exp, s)
+			}
+			continue
+		}
+		p := NewTagParser(taginfo.All(), lexers...)
+		if r := p.ParseRelease([]byte("Some.Movie.2019.Box1.1080p.BluRay.x264-GRP")); r.Title != "Some Movie" || r.Disc != "" {
+			t.Errorf("test %d expected title %q and no disc, got: %q %q", i, "Some Movie", r.Title, r.Disc)
+		}
+	}
+}
+
+func TestRegisterTagType(t *testing.T) {
+	t.Cleanup(unregisterTagTypes)
+	typ := RegisterTagType("Rating")
+	if !typ.Custom() {
+		t.Fatalf("expected %d to be custom", typ)
+	}
+	if s, exp := typ.Name(), "Rating"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if v := RegisterTagType("Rating"); v != typ {
+		t.Errorf("expected %d, got: %d", typ, v)
+	}
+	if TagTypeExt.Custom() {
+		t.Errorf("expected %s to not be custom", TagTypeExt)
+	}
+	if v := RegisterTagType("Part"); v != TagTypePart {
+		t.Errorf("expected %d, got: %d", TagTypePart, v)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if v := RegisterTagType("Rating"); v != typ || v.Name() != "Rating" {
+				t.Errorf("goroutine %d expected %d, got: %d (%s)", i, typ, v, v.Name())
+			}
+		}(i)
+	}
+	wg.Wait()
+	infos := taginfo.All(map[string][]*taginfo.Taginfo{
+		"rating": {
+			taginfo.Must("PG-13", "", `pg[\-\._ ]?13`, "", "", ""),
+			taginfo.Must("R", "", `(?-i:R)`, "", "", ""),
+		},
+	})
+	p := NewTagParser(infos, append(DefaultLexers(), NewRegexpLexer(typ, true))...)
+	for i, test := range []struct {
+		s     string
+		title string
+		exp   []string
+		e     string
+	}{
+		{"Movie.2019.PG13.1080p.BluRay.x264-GRP", "Movie", []string{"PG-13"}, "<Rating:PG-13>"},
+		{"Movie 2019 R 720p", "Movie", []string{"R"}, "<Rating:R>"},
+		{"Movie 2019 720p", "Movie", nil, ""},
+	} {
+		r := p.ParseRelease([]byte(test.s))
+		if r.Title != test.title {
+			t.Errorf("test %d expected title %q, got: %q", i, test.title, r.Title)
+		}
+		if !cmp.Equal(r.Extra["rating"], test.exp) {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, r.Extra["rating"])
+		}
+		tags, _ := Find(r.Tags(), "", -1, 's', typ)
+		if s := joinTags(tags, "%e", " "); s != test.e {
+			t.Errorf("test %d expected %q, got: %q", i, test.e, s)
+		}
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	t.Cleanup(unregisterTypes)
+	typ, err := RegisterType("podcast", 11)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !typ.Custom() {
+		t.Fatalf("expected %d to be custom", typ)
+	}
+	if s, exp := typ.String(), "podcast"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if v := ParseType("podcast"); v != typ {
+		t.Errorf("expected %d, got: %d", typ, v)
+	}
+	for _, name := range []string{"podcast", "movie", ""} {
+		if _, err := RegisterType(name, 1); err == nil {
+			t.Errorf("expected error registering %q", name)
+		}
+	}
+	if v, exp := typ.precedence(), 11; v != exp {
+		t.Errorf("expected %d, got: %d", exp, v)
+	}
+	if v, exp := Movie.precedence(), 1; v != exp {
+		t.Errorf("expected %d, got: %d", exp, v)
+	}
+	if _, ok := CompareMap[typ]; ok {
+		t.Errorf("expected %s to not be in CompareMap", typ)
+	}
+	if Series.Custom() {
+		t.Errorf("expected %s to not be custom", Series)
+	}
+	if _, err := taginfo.LoadBytes([]byte("Type,Tag,Title,Regexp,Other,ReleaseType,TypeExclusive\nother,CONCERT,,,,concert,1\n")); err == nil {
+		t.Errorf("expected error for unregistered type")
+	}
+}
+
+// unregisterTagTypes unregisters all custom tag types.
+func unregisterTagTypes() {
+	customTagTypes.Lock()
+	defer customTagTypes.Unlock()
+	customTagTypes.names = nil
+}
+
+// unregisterTypes unregisters all custom release types.
+func unregisterTypes() {
+	customTypes.Lock()
+	defer customTypes.Unlock()
+	for _, name := range customTypes.names {
+		taginfo.UnregisterType(name)
+	}
+	customTypes.names, customTypes.compare = nil, nil
+}
+
 func TestCollapser(t *testing.T) {
 	tests := []struct {
 		s string
@@ -260,6 +538,85 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareThreeD(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Avatar.2009.1080p.BluRay.x264-ZZZ", "Avatar.2009.3D.1080p.BluRay.Half-SBS.x264-AAA"},
+		{"Avatar.2009.3D.1080p.BluRay.x264-ZZZ", "Avatar.2009.3D.1080p.BluRay.Half-OU.x264-AAA"},
+		{"Avatar.2009.3D.1080p.BluRay.Half-OU.x264-ZZZ", "Avatar.2009.3D.1080p.BluRay.Half-SBS.x264-AAA"},
+	}
+	for i, test := range tests {
+		a, b := ParseString(test.a), ParseString(test.b)
+		if cmp := Compare(a, b); cmp != -1 {
+			t.Errorf("test %d expected %q (%s) < %q (%s), got: %d", i, test.a, a.ThreeD, test.b, b.ThreeD, cmp)
+		}
+	}
+}
+
+func TestAudioTracks(t *testing.T) {
+	for i, test := range []struct {
+		s   string
+		exp string
+	}{
+		{"Movie.2019.1080p.BluRay.DDP5.1.Atmos.DTS-HD.MA.7.1.x264-GRP", "DDP 5.1 object, DTS-HD.MA 7.1"},
+		{"Movie.2019.German.DTS.5.1.English.AC3.2.0.1080p.BluRay.x264-GRP", "GERMAN DTS 5.1, ENGLiSH DD 2.0"},
+		{"Movie.2019.1080p.WEB.h264-GRP", ""},
+	} {
+		tags, _ := ParseTagsString(test.s)
+		var v []string
+		for _, track := range AudioTracks(tags) {
+			v = append(v, track.String())
+		}
+		if s := strings.Join(v, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestCompareBitDepth(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Movie.2019.2160p.WEB.x265-ZZZ", "Movie.2019.2160p.WEB.10bit.x265-AAA"},
+		{"Movie.2019.2160p.WEB.8bit.x265-ZZZ", "Movie.2019.2160p.WEB.yuv420p10.x265-AAA"},
+		{"Movie.2019.2160p.WEB.10bit.x265-ZZZ", "Movie.2019.2160p.WEB.12bit.x265-AAA"},
+		{"Movie.2019.1080p.WEB.12bit.x265-ZZZ", "Movie.2019.2160p.WEB.8bit.x265-AAA"},
+	}
+	for i, test := range tests {
+		a, b := ParseString(test.a), ParseString(test.b)
+		if cmp := Compare(a, b); cmp != -1 {
+			t.Errorf("test %d expected %q (%d) < %q (%d), got: %d", i, test.a, a.BitDepth, test.b, b.BitDepth, cmp)
+		}
+	}
+}
+
+func TestCompareMusicQuality(t *testing.T) {
+	exp := []string{
+		"Artist - Album (2019) [MP3]",
+		"Artist - Album (2019) [MP3 192]",
+		"Artist - Album (2019) [MP3 320]",
+		"Artist - Album (2019) [MP3 V2]",
+		"Artist - Album (2019) [MP3 V0]",
+		"Artist - Album (2019) [FLAC]",
+		"Artist - Album (2019) [FLAC 16-44.1]",
+		"Artist - Album (2019) [FLAC 24-48]",
+		"Artist - Album (2019) [FLAC 24-96]",
+	}
+	releases := make([]Release, len(exp))
+	for i, j := range rand.Perm(len(exp)) {
+		releases[i] = ParseString(exp[j])
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return CompareMusicQuality(releases[i], releases[j]) < 0
+	})
+	for i, r := range releases {
+		if s := fmt.Sprintf("%o", r); s != exp[i] {
+			t.Errorf("test %d expected %q, got: %q (%s)", i, exp[i], s, r.MusicQuality)
+		}
+	}
+}
+
 func TestFind(t *testing.T) {
 	f := genre()
 	tags := []Tag{
@@ -444,6 +801,46 @@ func TestExport_tests(t *testing.T) {
 			switch name {
 			case "seriesepisodes":
 				name = "seriesEpisodes"
+			case "bitdepth":
+				name = "bitDepth"
+			case "framerate":
+				name = "frameRate"
+			case "audiotracks":
+				name = "audioTracks"
+			case "audiolanguages":
+				name = "audioLanguages"
+			case "subtitlelanguages":
+				name = "subtitleLanguages"
+			case "hardcodedsubs":
+				name = "hardcodedSubs"
+			case "languagecount":
+				name = "languageCount"
+			case "languagetags":
+				name = "languageTags"
+			case "musicquality":
+				name = "musicQuality"
+			case "musicformat":
+				name = "musicFormat"
+			case "bookformat":
+				name = "bookFormat"
+			case "issueend":
+				name = "issueEnd"
+			case "issuetotal":
+				name = "issueTotal"
+			case "disctotal":
+				name = "discTotal"
+			case "parttotal":
+				name = "partTotal"
+			case "threed":
+				name = "threeD"
+			case "revisionmarkers":
+				name = "revisionMarkers"
+			case "imdbid":
+				name = "imdbID"
+			case "tmdbid":
+				name = "tmdbID"
+			case "tvdbid":
+				name = "tvdbID"
 			}
 			switch v.Field(j).Kind() {
 			case reflect.Int:
@@ -491,6 +888,8 @@ func TestExport_taginfo(t *testing.T) {
 				info.Other(),
 				Type(info.Type()).String(),
 				excl,
+				info.Kind(),
+				info.Code(),
 			})
 		}
 	}
@@ -520,8 +919,12 @@ func TestExport_taginfo(t *testing.T) {
 		return cmp < 0
 	})
 	buf := new(bytes.Buffer)
-	_, _ = buf.WriteString("Type,Tag,Title,Regexp,Other,ReleaseType,TypeExclusive\n")
+	_, _ = buf.WriteString("Type,Tag,Title,Regexp,Other,ReleaseType,TypeExclusive,Kind,Code\n")
 	for _, v := range all {
+		// drop empty optional columns
+		for len(v) > 7 && v[len(v)-1] == "" {
+			v = v[:len(v)-1]
+		}
 		_, _ = buf.WriteString(strings.Join(v, ",") + "\n")
 	}
 	if err := os.WriteFile("taginfo/taginfo.csv", buf.Bytes(), 0o644); err != nil {
@@ -716,7 +1119,12 @@ type rls struct {
 
 	Source     string
 	Resolution string
+	FrameRate  string
+	HFR        int
 	Collection string
+	Service    string
+	Network    string
+	Publisher  string
 
 	Year  int
 	Month int
@@ -727,22 +1135,63 @@ type rls struct {
 	SeriesEpisodes string
 	Version        string
 	Disc           string
+	DiscTotal      int
+	Part           int
+	PartTotal      int
 
-	Codec    string
-	HDR      string
-	Audio    string
-	Channels string
-	Other    string
-	Cut      string
-	Edition  string
-	Language string
+	Codec        string
+	HDR          string
+	BitDepth     int
+	Chroma       string
+	ThreeD       string
+	Audio        string
+	Channels     string
+	AudioTracks  string
+	MusicQuality string
+	Other        string
+	Cut          string
+	Edition      string
+	Language     string
+
+	Revision        int
+	RevisionMarkers string
+
+	Game     string
+	Software string
+	Sports   string
+
+	AudioLanguages    string
+	SubtitleLanguages string
+	HardcodedSubs     int
+	LanguageCount     int
+	LanguageTags      string
+
+	Label       string
+	Catalog     string
+	MusicFormat string
+
+	ISBN       string
+	BookFormat string
+	Narrator   string
+	Retail     int
+	Unabridged int
+
+	Volume     int
+	Issue      int
+	IssueEnd   int
+	IssueTotal int
+	Annual     int
 
 	Size      string
 	Region    string
 	Container string
 	Genre     string
 	ID        string
+	IMDbID    string
+	TMDbID    int
+	TVDbID    int
 	Group     string
+	Origin    string
 	Meta      string
 	Site      string
 	Sum       string
@@ -751,6 +1200,8 @@ type rls struct {
 	Ext       string
 
 	Unused string
+
+	Parser string
 }
 
 func buildRls(r Release) rls {
@@ -758,12 +1209,39 @@ func buildRls(r Release) rls {
 	if r.Req {
 		req = 1
 	}
+	hfr := 0
+	if r.HFR {
+		hfr = 1
+	}
+	hardcodedSubs := 0
+	if r.HardcodedSubs {
+		hardcodedSubs = 1
+	}
+	retail, unabridged := 0, 0
+	if r.Retail {
+		retail = 1
+	}
+	if r.Unabridged {
+		unabridged = 1
+	}
 	var seriesEpisodes []string
 	if eps := r.SeriesEpisodes(); len(eps) > 1 {
 		for _, ep := range eps {
 			seriesEpisodes = append(seriesEpisodes, fmt.Sprintf("S%02dE%02d", ep[0], ep[1]))
 		}
 	}
+	var tracks []string
+	for _, track := range r.AudioTracks {
+		tracks = append(tracks, track.String())
+	}
+	var languageTags []string
+	for _, tag := range r.LanguageTags() {
+		languageTags = append(languageTags, tag.String())
+	}
+	var frameRate string
+	if r.FrameRate != 0 {
+		frameRate = strconv.FormatFloat(r.FrameRate, 'f', -1, 64)
+	}
 	return rls{
 		Type: r.Type.String(),
 
@@ -777,7 +1255,12 @@ func buildRls(r Release) rls {
 
 		Source:     r.Source,
 		Resolution: r.Resolution,
+		FrameRate:  frameRate,
+		HFR:        hfr,
 		Collection: r.Collection,
+		Service:    strings.Join(r.Service, " "),
+		Network:    strings.Join(r.Network, " "),
+		Publisher:  strings.Join(r.Publisher, " "),
 
 		Year:  r.Year,
 		Month: r.Month,
@@ -788,22 +1271,63 @@ func buildRls(r Release) rls {
 		SeriesEpisodes: strings.Join(seriesEpisodes, " "),
 		Version:        r.Version,
 		Disc:           r.Disc,
+		DiscTotal:      r.DiscTotal,
+		Part:           r.Part,
+		PartTotal:      r.PartTotal,
+
+		Codec:        strings.Join(r.Codec, " "),
+		HDR:          strings.Join(r.HDR, " "),
+		BitDepth:     r.BitDepth,
+		Chroma:       r.Chroma,
+		ThreeD:       r.ThreeD.String(),
+		Audio:        strings.Join(r.Audio, " "),
+		Channels:     r.Channels,
+		AudioTracks:  strings.Join(tracks, ", "),
+		MusicQuality: r.MusicQuality.String(),
+		Other:        strings.Join(r.Other, " "),
+		Cut:          strings.Join(r.Cut, " "),
+		Edition:      strings.Join(r.Edition, " "),
+		Language:     strings.Join(r.Language, " "),
+
+		Revision:        r.Revision,
+		RevisionMarkers: strings.Join(r.RevisionMarkers, " "),
+
+		Game:     r.Game.String(),
+		Software: r.Software.String(),
+		Sports:   r.Sports.String(),
 
-		Codec:    strings.Join(r.Codec, " "),
-		HDR:      strings.Join(r.HDR, " "),
-		Audio:    strings.Join(r.Audio, " "),
-		Channels: r.Channels,
-		Other:    strings.Join(r.Other, " "),
-		Cut:      strings.Join(r.Cut, " "),
-		Edition:  strings.Join(r.Edition, " "),
-		Language: strings.Join(r.Language, " "),
+		AudioLanguages:    strings.Join(r.AudioLanguages, " "),
+		SubtitleLanguages: strings.Join(r.SubtitleLanguages, " "),
+		HardcodedSubs:     hardcodedSubs,
+		LanguageCount:     r.LanguageCount,
+		LanguageTags:      strings.Join(languageTags, " "),
+
+		Label:       r.Label,
+		Catalog:     r.Catalog,
+		MusicFormat: r.MusicFormat,
+
+		ISBN:       r.ISBN,
+		BookFormat: r.BookFormat,
+		Narrator:   r.Narrator,
+		Retail:     retail,
+		Unabridged: unabridged,
+
+		Volume:     r.Volume,
+		Issue:      r.Issue,
+		IssueEnd:   r.IssueEnd,
+		IssueTotal: r.IssueTotal,
+		Annual:     r.Annual,
 
 		Size:      r.Size,
 		Region:    r.Region,
 		Container: r.Container,
 		Genre:     r.Genre,
 		ID:        r.ID,
+		IMDbID:    r.IMDbID,
+		TMDbID:    r.TMDbID,
+		TVDbID:    r.TVDbID,
 		Group:     r.Group,
+		Origin:    r.Origin.String(),
 		Meta:      strings.Join(r.Meta, " "),
 		Site:      r.Site,
 		Sum:       r.Sum,
@@ -848,8 +1372,10 @@ func rlsTests(tb testing.TB) []rlsTest {
 			}
 			name := strings.ToUpper(string(line[2:3])) + string(line[3:n])
 			switch name {
-			case "Id", "Hdr":
+			case "Id", "Hdr", "Hfr", "Isbn":
 				name = strings.ToUpper(name)
+			case "ImdbID", "TmdbID", "TvdbID":
+				name = strings.ToUpper(name[:3]) + name[3:]
 			}
 			f := reflect.ValueOf(&test.exp).Elem().FieldByName(name)
 			switch f.Kind() {
@@ -877,6 +1403,51 @@ func rlsTests(tb testing.TB) []rlsTest {
 	return append(tests, test)
 }
 
+// testParsers returns the parsers used by the tests, keyed by the name used
+// in the parser field of tests.yaml.
+func testParsers(tb testing.TB) map[string]Parser {
+	tb.Helper()
+	if _, err := RegisterType("podcast", 11); err != nil {
+		tb.Fatalf("expected no error, got: %v", err)
+	}
+	tb.Cleanup(unregisterTypes)
+	podcast, err := taginfo.LoadBytes([]byte("Type,Tag,Title,Regexp,Other,ReleaseType,TypeExclusive\nother,PODCAST,Podcast,,,podcast,1\n"))
+	if err != nil {
+		tb.Fatalf("expected no error, got: %v", err)
+	}
+	titles, err := LoadTitles(strings.NewReader("The Limited Series\nThe Proper Extended Cut\nSpider-Man: No Way Home\nThe Proper\n"))
+	if err != nil {
+		tb.Fatalf("expected no error, got: %v", err)
+	}
+	delims := DefaultDelims()
+	delims.Any += "|·・　"
+	delims.Break += "|·・　"
+	delims.TitleTrim += "|·・　"
+	b := NewTagBuilder()
+	if err := b.RemoveStage("fixMusic"); err != nil {
+		tb.Fatalf("expected no error, got: %v", err)
+	}
+	if err := b.InsertStage("", aliasStage); err != nil {
+		tb.Fatalf("expected no error, got: %v", err)
+	}
+	infos := taginfo.All(groupInfos())
+	return map[string]Parser{
+		"":        NewTagParser(infos, DefaultLexers()...),
+		"anime":   NewTagParserWithOptions(infos, DefaultLexers(), WithAnime()),
+		"delims":  NewTagParserWithOptions(infos, DefaultLexers(), WithDelims(delims)),
+		"podcast": NewTagParser(taginfo.All(groupInfos(), podcast), DefaultLexers()...),
+		"stages":  NewTagParserWithOptions(infos, DefaultLexers(), WithBuilder(b)),
+		"titles":  NewTagParser(infos, append(DefaultLexers(), NewTitleLexer(titles...))...),
+	}
+}
+
+// aliasStage is a custom stage renaming the GRP group.
+var aliasStage = Stage{"alias", func(_ *TagBuilder, r *Release) {
+	if r.Group == "GRP" {
+		r.Group = "GROUP"
+	}
+}}
+
 // genre returns a find func for the embedded genres.
 func genre() taginfo.FindFunc {
 	return taginfo.Find(taginfo.All()["genre"]...)
@@ -894,14 +1465,15 @@ func joinTags(tags []Tag, str, sep string) string {
 func groupInfos() map[string][]*taginfo.Taginfo {
 	var groups []*taginfo.Taginfo
 	for _, group := range []struct {
-		tag, typ string
+		tag, typ, kind string
 	}{
-		{"CODEX", "game"},
-		{"DARKSiDERS", "game"},
-		{"D-Z0N3", "movie"},
-		{"MrSeeN-SiMPLE", ""},
+		{"CODEX", "game", "scene"},
+		{"DARKSiDERS", "game", "scene"},
+		{"D-Z0N3", "movie", "internal"},
+		{"FraMeSToR", "movie", "p2p"},
+		{"MrSeeN-SiMPLE", "", ""},
 	} {
-		groups = append(groups, taginfo.Must(group.tag, "", "", "", group.typ, ""))
+		groups = append(groups, taginfo.Must(group.tag, "", "", "", group.typ, "", group.kind))
 	}
 	return map[string][]*taginfo.Taginfo{
 		"group": groups,
@@ -11,19 +11,39 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
 )
 
 // columns is the columns count.
-const columns = 6
+const columns = 8
 
-// RegisterType registers release types.
+// optional is the count of optional trailing columns.
+const optional = 2
+
+// headers are the csv headers. The last headers (Kind, Code) are optional.
+var headers = []string{"Type", "Tag", "Title", "Regexp", "Other", "ReleaseType", "TypeExclusive", "Kind", "Code"}
+
+// RegisterType registers release types. Safe for concurrent use.
 func RegisterType(typ string, i int) {
+	mu.Lock()
+	defer mu.Unlock()
 	types[typ] = i
 }
 
+// UnregisterType unregisters a release type. Safe for concurrent use.
+func UnregisterType(typ string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(types, typ)
+}
+
 // types is the map of string types.
 var types map[string]int
 
+// mu guards types.
+var mu sync.RWMutex
+
 func init() {
 	types = make(map[string]int)
 }
@@ -36,24 +56,36 @@ type Taginfo struct {
 	other  string
 	typ    int
 	excl   bool
+	kind   string
+	code   string
 	re     *regexp.Regexp
 }
 
-// New creates a new tag info.
+// New creates a new tag info. The last fields (kind, code) are optional.
 func New(strs ...string) (*Taginfo, error) {
-	if len(strs) != columns {
-		return nil, fmt.Errorf("tag info must have %d fields", columns)
+	if len(strs) < columns-optional || columns < len(strs) {
+		return nil, fmt.Errorf("tag info must have %d to %d fields", columns-optional, columns)
 	}
 	tag, title, re, other, typstr, excl := strs[0], strs[1], strs[2], strs[3], strs[4], strs[5] == "1"
+	var kind, code string
+	if len(strs) > 6 {
+		kind = strs[6]
+	}
+	if len(strs) > 7 {
+		code = strs[7]
+	}
+	mu.RLock()
 	typ, ok := types[typstr]
+	mu.RUnlock()
 	switch {
 	case tag == "":
 		return nil, errors.New("must define tag")
-	case title == "":
-		title = tag
 	case !ok:
 		return nil, fmt.Errorf("invalid type %q", typstr)
 	}
+	if title == "" {
+		title = tag
+	}
 	info := &Taginfo{
 		tag:    tag,
 		title:  title,
@@ -61,6 +93,8 @@ func New(strs ...string) (*Taginfo, error) {
 		other:  other,
 		excl:   excl,
 		typ:    typ,
+		kind:   kind,
+		code:   code,
 	}
 	var err error
 	if info.re, err = regexp.Compile(`(?i)^(?:` + info.RE() + `)$`); err != nil {
@@ -72,16 +106,18 @@ func New(strs ...string) (*Taginfo, error) {
 // Load loads a set of csv tag info from the reader.
 func Load(rdr io.Reader) (map[string][]*Taginfo, error) {
 	r := csv.NewReader(rdr)
+	// rows may omit the optional trailing columns
+	r.FieldsPerRecord = -1
 	// check header
 	switch v, err := r.Read(); {
 	case err != nil && errors.Is(err, io.EOF):
 		return nil, errors.New("empty csv")
 	case err != nil:
 		return nil, err
-	case len(v) != columns+1:
-		return nil, fmt.Errorf("must have %d columns, got %q", columns+1, v)
-	case v[0] != "Type" || v[1] != "Tag" || v[2] != "Title" || v[3] != "Regexp" || v[4] != "Other" || v[5] != "ReleaseType" || v[6] != "TypeExclusive":
-		return nil, errors.New("must have csv headers Type, Tag, Title, Regexp, Other, ReleaseType, TypeExclusive")
+	case len(v) < columns+1-optional || columns+1 < len(v):
+		return nil, fmt.Errorf("must have %d to %d columns, got %q", columns+1-optional, columns+1, v)
+	case !equal(v, headers[:len(v)]):
+		return nil, fmt.Errorf("must have csv headers %s", strings.Join(headers[:len(v)], ", "))
 	}
 	m, exists := make(map[string][]*Taginfo), make(map[string]map[string]int64)
 	for i := int64(0); ; i++ {
@@ -202,6 +238,16 @@ func (info *Taginfo) Excl() bool {
 	return info.excl
 }
 
+// Kind returns the tag info kind.
+func (info *Taginfo) Kind() string {
+	return info.kind
+}
+
+// Code returns the tag info code.
+func (info *Taginfo) Code() string {
+	return info.code
+}
+
 // RE returns the tag info regexp string.
 func (info *Taginfo) RE() string {
 	if info.regexp != "" {
@@ -231,6 +277,19 @@ func Find(infos ...*Taginfo) FindFunc {
 	}
 }
 
+// equal returns true when a and b are equal.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // AllBytes returns embedded tag info in csv format.
 func AllBytes() []byte {
 	buf := make([]byte, len(taginfoCsv))
@@ -36,11 +36,19 @@ func _() {
 	_ = x[TagTypeGroup-25]
 	_ = x[TagTypeMeta-26]
 	_ = x[TagTypeExt-27]
+	_ = x[TagTypeBitDepth-28]
+	_ = x[TagTypeChroma-29]
+	_ = x[TagTypeFrameRate-30]
+	_ = x[TagTypeISBN-31]
+	_ = x[TagTypeIssue-32]
+	_ = x[TagTypeLeague-33]
+	_ = x[TagTypeRound-34]
+	_ = x[TagTypePart-35]
 }
 
-const _TagType_name = "WhitespaceDelimTextPlatformArchSourceResolutionCollectionDateSeriesVersionDiscCodecHDRAudioChannelsOtherCutEditionLanguageSizeRegionContainerGenreIDGroupMetaExt"
+const _TagType_name = "WhitespaceDelimTextPlatformArchSourceResolutionCollectionDateSeriesVersionDiscCodecHDRAudioChannelsOtherCutEditionLanguageSizeRegionContainerGenreIDGroupMetaExtBitDepthChromaFrameRateISBNIssueLeagueRoundPart"
 
-var _TagType_index = [...]uint8{0, 10, 15, 19, 27, 31, 37, 47, 57, 61, 67, 74, 78, 83, 86, 91, 99, 104, 107, 114, 122, 126, 132, 141, 146, 148, 153, 157, 160}
+var _TagType_index = [...]uint8{0, 10, 15, 19, 27, 31, 37, 47, 57, 61, 67, 74, 78, 83, 86, 91, 99, 104, 107, 114, 122, 126, 132, 141, 146, 148, 153, 157, 160, 168, 174, 183, 187, 192, 198, 203, 207}
 
 func (i TagType) String() string {
 	if i < 0 || i >= TagType(len(_TagType_index)-1) {
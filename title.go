@@ -0,0 +1,145 @@
+package rls
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/moistari/rls/taginfo"
+)
+
+// NewTitleLexer creates a tag lexer for known titles. When the start of a
+// release matches one of the titles, the matched words are captured as text,
+// preventing them from being lexed as other tags.
+//
+// Titles and release words are compared after normalization (see
+// MustNormalize), with any delimiter matching any other delimiter. The longest
+// matching title is used.
+func NewTitleLexer(titles ...string) Lexer {
+	delims := DefaultDelims()
+	var m map[string][][]string
+	var delim *regexp.Regexp
+	return TagLexer{
+		Delims: func(d Delims) {
+			delims = d
+		},
+		Init: func(_ map[string][]*taginfo.Taginfo, re *regexp.Regexp, _ map[string]bool) {
+			m, delim = make(map[string][][]string), re
+			for _, title := range titles {
+				if words := titleWords(title, delims.IsAny); len(words) != 0 {
+					m[words[0]] = append(m[words[0]], words)
+				}
+			}
+			// longest first
+			for _, v := range m {
+				sort.SliceStable(v, func(a, b int) bool {
+					return len(v[a]) > len(v[b])
+				})
+			}
+		},
+		Lex: func(src, buf []byte, start, end []Tag, i, n int) ([]Tag, []Tag, int, int, bool) {
+			if len(m) == 0 {
+				return start, end, i, n, false
+			}
+			// split words and delimiters
+			var tags []Tag
+			var words []string
+			var pos []int
+			for j := i; j < n; {
+				if d := delim.FindSubmatch(src[j:n]); d != nil {
+					tags, j = append(tags, NewTag(TagTypeDelim, nil, d[0], d[1])), j+len(d[0])
+					continue
+				}
+				k := j
+				for k < n && !delim.Match(src[k:n]) {
+					k++
+				}
+				for _, word := range titleWords(string(src[j:k]), delims.IsAny) {
+					words, pos = append(words, word), append(pos, len(tags))
+				}
+				tags, j = append(tags, NewTag(TagTypeText, nil, src[j:k], src[j:k])), k
+			}
+			// leading delimiters are left for the parser
+			if len(words) == 0 || pos[0] != 0 {
+				return start, end, i, n, false
+			}
+			for _, title := range m[words[0]] {
+				if len(title) > len(words) || !equalWords(title, words[:len(title)]) ||
+					(len(title) < len(words) && pos[len(title)] == pos[len(title)-1]) {
+					continue
+				}
+				for _, tag := range tags[:pos[len(title)-1]+1] {
+					start, i = append(start, tag), i+len(tag.v[0])
+				}
+				return start, end, i, n, true
+			}
+			return start, end, i, n, false
+		},
+		Once: true,
+	}
+}
+
+// titleWords splits s into normalized words.
+func titleWords(s string, f func(rune) bool) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(s, f) {
+		words = append(words, strings.Fields(MustNormalize(field))...)
+	}
+	return words
+}
+
+// equalWords returns true when a and b contain the same words.
+func equalWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadTitles loads known titles from the reader, one title per line. Blank
+// lines and lines starting with '#' are ignored.
+func LoadTitles(rdr io.Reader) ([]string, error) {
+	var titles []string
+	s := bufio.NewScanner(rdr)
+	for s.Scan() {
+		switch line := strings.TrimSpace(s.Text()); {
+		case line == "", strings.HasPrefix(line, "#"):
+		default:
+			titles = append(titles, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return titles, nil
+}
+
+// LoadTitlesFile loads known titles from a file.
+func LoadTitlesFile(file string) ([]string, error) {
+	f, err := os.OpenFile(file, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+	defer f.Close()
+	titles, err := LoadTitles(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+	}
+	return titles, nil
+}
+
+// LoadTitlesBytes loads known titles from buf.
+func LoadTitlesBytes(buf []byte) ([]string, error) {
+	return LoadTitles(bytes.NewReader(buf))
+}